@@ -0,0 +1,494 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Analyzer is an analyzer. It's either an analyzer class e.g.
+// "org.apache.lucene.analysis.core.WhitespaceAnalyzer" or a tokenizer
+// with optional char filters and filters.
+//
+// Refer to https://solr.apache.org/guide/8_8/analyzers.html
+type Analyzer struct {
+	Class       string     `json:"class,omitempty"`
+	Tokenizer   *Tokenizer `json:"tokenizer,omitempty"`
+	Filters     []Filter   `json:"filters,omitempty"`
+	CharFilters []Filter   `json:"charFilters,omitempty"`
+}
+
+// NewAnalyzer returns a new Analyzer with the tokenizer and the filters
+func NewAnalyzer(tokenizer Tokenizer, filters ...Filter) *Analyzer {
+	return &Analyzer{Tokenizer: &tokenizer, Filters: filters}
+}
+
+// WithCharFilters sets the char filters that are applied before the tokenizer
+func (a *Analyzer) WithCharFilters(charFilters ...Filter) *Analyzer {
+	a.CharFilters = charFilters
+	return a
+}
+
+// AnalyzerComponent is a tokenizer, filter or char filter. It's identified by either
+// the class e.g. "solr.LowerCaseFilterFactory" or the name e.g. "lowercase" (Solr 9+).
+// The params are encoded in order after the name and the class.
+type AnalyzerComponent struct {
+	Name   string
+	Class  string
+	Params []AnalyzerParam
+}
+
+// AnalyzerParam is a param of a tokenizer, filter or char filter
+type AnalyzerParam struct {
+	Name  string
+	Value interface{}
+}
+
+// Tokenizer is a tokenizer
+type Tokenizer = AnalyzerComponent
+
+// Filter is a filter or a char filter
+type Filter = AnalyzerComponent
+
+// NewAnalyzerComponent returns a new AnalyzerComponent with the class
+func NewAnalyzerComponent(class string) AnalyzerComponent {
+	return AnalyzerComponent{Class: class}
+}
+
+// With returns a copy of the component with the param set, replacing the param with the same name
+func (c AnalyzerComponent) With(name string, value interface{}) AnalyzerComponent {
+	params := make([]AnalyzerParam, 0, len(c.Params)+1)
+	replaced := false
+	for _, param := range c.Params {
+		if param.Name == name {
+			param.Value = value
+			replaced = true
+		}
+		params = append(params, param)
+	}
+
+	if !replaced {
+		params = append(params, AnalyzerParam{Name: name, Value: value})
+	}
+
+	c.Params = params
+	return c
+}
+
+// Param returns the value of the param
+func (c AnalyzerComponent) Param(name string) (interface{}, bool) {
+	for _, param := range c.Params {
+		if param.Name == name {
+			return param.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// MarshalJSON implements json.Marshaler
+func (c AnalyzerComponent) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	write := func(name string, value interface{}) error {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(b)
+		return nil
+	}
+
+	if c.Name != "" {
+		_ = write("name", c.Name)
+	}
+
+	if c.Class != "" {
+		_ = write("class", c.Class)
+	}
+
+	for _, param := range c.Params {
+		if param.Name == "name" || param.Name == "class" {
+			continue
+		}
+
+		err := write(param.Name, param.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the params are kept in order
+func (c *AnalyzerComponent) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	err := expectDelim(dec, '{')
+	if err != nil {
+		return err
+	}
+
+	*c = AnalyzerComponent{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return wrapErr(err, "read key")
+		}
+		key, _ := tok.(string)
+
+		var value interface{}
+		err = dec.Decode(&value)
+		if err != nil {
+			return wrapErr(err, "decode "+key)
+		}
+
+		switch key {
+		case "name":
+			c.Name = fmt.Sprint(value)
+		case "class":
+			c.Class = fmt.Sprint(value)
+		default:
+			c.Params = append(c.Params, AnalyzerParam{Name: key, Value: value})
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// Tokenizers
+//
+// Refer to https://solr.apache.org/guide/8_8/tokenizers.html
+
+// NewStandardTokenizer returns a solr.StandardTokenizerFactory
+func NewStandardTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.StandardTokenizerFactory")
+}
+
+// NewClassicTokenizer returns a solr.ClassicTokenizerFactory
+func NewClassicTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.ClassicTokenizerFactory")
+}
+
+// NewWhitespaceTokenizer returns a solr.WhitespaceTokenizerFactory
+func NewWhitespaceTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.WhitespaceTokenizerFactory")
+}
+
+// NewKeywordTokenizer returns a solr.KeywordTokenizerFactory
+func NewKeywordTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.KeywordTokenizerFactory")
+}
+
+// NewLetterTokenizer returns a solr.LetterTokenizerFactory
+func NewLetterTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.LetterTokenizerFactory")
+}
+
+// NewUAX29URLEmailTokenizer returns a solr.UAX29URLEmailTokenizerFactory
+func NewUAX29URLEmailTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.UAX29URLEmailTokenizerFactory")
+}
+
+// NewPathHierarchyTokenizer returns a solr.PathHierarchyTokenizerFactory
+func NewPathHierarchyTokenizer(delimiter string) Tokenizer {
+	return NewAnalyzerComponent("solr.PathHierarchyTokenizerFactory").
+		With("delimiter", delimiter)
+}
+
+// NewPatternTokenizer returns a solr.PatternTokenizerFactory
+func NewPatternTokenizer(pattern string) Tokenizer {
+	return NewAnalyzerComponent("solr.PatternTokenizerFactory").
+		With("pattern", pattern)
+}
+
+// NewNGramTokenizer returns a solr.NGramTokenizerFactory
+func NewNGramTokenizer(minGramSize, maxGramSize int) Tokenizer {
+	return NewAnalyzerComponent("solr.NGramTokenizerFactory").
+		With("minGramSize", minGramSize).
+		With("maxGramSize", maxGramSize)
+}
+
+// NewEdgeNGramTokenizer returns a solr.EdgeNGramTokenizerFactory
+func NewEdgeNGramTokenizer(minGramSize, maxGramSize int) Tokenizer {
+	return NewAnalyzerComponent("solr.EdgeNGramTokenizerFactory").
+		With("minGramSize", minGramSize).
+		With("maxGramSize", maxGramSize)
+}
+
+// NewICUTokenizer returns a solr.ICUTokenizerFactory, requires the analysis-extras contrib
+func NewICUTokenizer() Tokenizer {
+	return NewAnalyzerComponent("solr.ICUTokenizerFactory")
+}
+
+// Char filters
+//
+// Refer to https://solr.apache.org/guide/8_8/charfilterfactories.html
+
+// NewHTMLStripCharFilter returns a solr.HTMLStripCharFilterFactory
+func NewHTMLStripCharFilter() Filter {
+	return NewAnalyzerComponent("solr.HTMLStripCharFilterFactory")
+}
+
+// NewMappingCharFilter returns a solr.MappingCharFilterFactory
+func NewMappingCharFilter(mapping string) Filter {
+	return NewAnalyzerComponent("solr.MappingCharFilterFactory").
+		With("mapping", mapping)
+}
+
+// NewPatternReplaceCharFilter returns a solr.PatternReplaceCharFilterFactory
+func NewPatternReplaceCharFilter(pattern, replacement string) Filter {
+	return NewAnalyzerComponent("solr.PatternReplaceCharFilterFactory").
+		With("pattern", pattern).
+		With("replacement", replacement)
+}
+
+// NewICUNormalizer2CharFilter returns a solr.ICUNormalizer2CharFilterFactory, requires the analysis-extras contrib
+func NewICUNormalizer2CharFilter() Filter {
+	return NewAnalyzerComponent("solr.ICUNormalizer2CharFilterFactory")
+}
+
+// Filters
+//
+// Refer to https://solr.apache.org/guide/8_8/filter-descriptions.html
+
+// NewLowerCaseFilter returns a solr.LowerCaseFilterFactory
+func NewLowerCaseFilter() Filter {
+	return NewAnalyzerComponent("solr.LowerCaseFilterFactory")
+}
+
+// NewUpperCaseFilter returns a solr.UpperCaseFilterFactory
+func NewUpperCaseFilter() Filter {
+	return NewAnalyzerComponent("solr.UpperCaseFilterFactory")
+}
+
+// NewASCIIFoldingFilter returns a solr.ASCIIFoldingFilterFactory
+func NewASCIIFoldingFilter() Filter {
+	return NewAnalyzerComponent("solr.ASCIIFoldingFilterFactory")
+}
+
+// NewICUFoldingFilter returns a solr.ICUFoldingFilterFactory, requires the analysis-extras contrib
+func NewICUFoldingFilter() Filter {
+	return NewAnalyzerComponent("solr.ICUFoldingFilterFactory")
+}
+
+// NewClassicFilter returns a solr.ClassicFilterFactory
+func NewClassicFilter() Filter {
+	return NewAnalyzerComponent("solr.ClassicFilterFactory")
+}
+
+// NewStopFilter returns a solr.StopFilterFactory with the stop words file
+func NewStopFilter(words string) Filter {
+	return NewAnalyzerComponent("solr.StopFilterFactory").
+		With("words", words)
+}
+
+// NewManagedStopFilter returns a solr.ManagedStopFilterFactory with the managed resource name
+func NewManagedStopFilter(managed string) Filter {
+	return NewAnalyzerComponent("solr.ManagedStopFilterFactory").
+		With("managed", managed)
+}
+
+// NewCommonGramsFilter returns a solr.CommonGramsFilterFactory with the common words file
+func NewCommonGramsFilter(words string) Filter {
+	return NewAnalyzerComponent("solr.CommonGramsFilterFactory").
+		With("words", words)
+}
+
+// NewSynonymGraphFilter returns a solr.SynonymGraphFilterFactory with the synonyms file
+func NewSynonymGraphFilter(synonyms string) Filter {
+	return NewAnalyzerComponent("solr.SynonymGraphFilterFactory").
+		With("synonyms", synonyms)
+}
+
+// NewManagedSynonymGraphFilter returns a solr.ManagedSynonymGraphFilterFactory with the managed resource name
+func NewManagedSynonymGraphFilter(managed string) Filter {
+	return NewAnalyzerComponent("solr.ManagedSynonymGraphFilterFactory").
+		With("managed", managed)
+}
+
+// NewFlattenGraphFilter returns a solr.FlattenGraphFilterFactory,
+// required after graph filters in the index analyzer
+func NewFlattenGraphFilter() Filter {
+	return NewAnalyzerComponent("solr.FlattenGraphFilterFactory")
+}
+
+// NewWordDelimiterGraphFilter returns a solr.WordDelimiterGraphFilterFactory
+func NewWordDelimiterGraphFilter() Filter {
+	return NewAnalyzerComponent("solr.WordDelimiterGraphFilterFactory")
+}
+
+// NewEdgeNGramFilter returns a solr.EdgeNGramFilterFactory
+func NewEdgeNGramFilter(minGramSize, maxGramSize int) Filter {
+	return NewAnalyzerComponent("solr.EdgeNGramFilterFactory").
+		With("minGramSize", minGramSize).
+		With("maxGramSize", maxGramSize)
+}
+
+// NewNGramFilter returns a solr.NGramFilterFactory
+func NewNGramFilter(minGramSize, maxGramSize int) Filter {
+	return NewAnalyzerComponent("solr.NGramFilterFactory").
+		With("minGramSize", minGramSize).
+		With("maxGramSize", maxGramSize)
+}
+
+// NewShingleFilter returns a solr.ShingleFilterFactory
+func NewShingleFilter(minShingleSize, maxShingleSize int) Filter {
+	return NewAnalyzerComponent("solr.ShingleFilterFactory").
+		With("minShingleSize", minShingleSize).
+		With("maxShingleSize", maxShingleSize)
+}
+
+// NewPorterStemFilter returns a solr.PorterStemFilterFactory
+func NewPorterStemFilter() Filter {
+	return NewAnalyzerComponent("solr.PorterStemFilterFactory")
+}
+
+// NewKStemFilter returns a solr.KStemFilterFactory
+func NewKStemFilter() Filter {
+	return NewAnalyzerComponent("solr.KStemFilterFactory")
+}
+
+// NewSnowballPorterFilter returns a solr.SnowballPorterFilterFactory for the language e.g. "English"
+func NewSnowballPorterFilter(language string) Filter {
+	return NewAnalyzerComponent("solr.SnowballPorterFilterFactory").
+		With("language", language)
+}
+
+// NewEnglishMinimalStemFilter returns a solr.EnglishMinimalStemFilterFactory
+func NewEnglishMinimalStemFilter() Filter {
+	return NewAnalyzerComponent("solr.EnglishMinimalStemFilterFactory")
+}
+
+// NewEnglishPossessiveFilter returns a solr.EnglishPossessiveFilterFactory
+func NewEnglishPossessiveFilter() Filter {
+	return NewAnalyzerComponent("solr.EnglishPossessiveFilterFactory")
+}
+
+// NewHunspellStemFilter returns a solr.HunspellStemFilterFactory
+func NewHunspellStemFilter(dictionary, affix string) Filter {
+	return NewAnalyzerComponent("solr.HunspellStemFilterFactory").
+		With("dictionary", dictionary).
+		With("affix", affix)
+}
+
+// NewKeywordMarkerFilter returns a solr.KeywordMarkerFilterFactory with the protected words file
+func NewKeywordMarkerFilter(protected string) Filter {
+	return NewAnalyzerComponent("solr.KeywordMarkerFilterFactory").
+		With("protected", protected)
+}
+
+// NewRemoveDuplicatesTokenFilter returns a solr.RemoveDuplicatesTokenFilterFactory
+func NewRemoveDuplicatesTokenFilter() Filter {
+	return NewAnalyzerComponent("solr.RemoveDuplicatesTokenFilterFactory")
+}
+
+// NewTrimFilter returns a solr.TrimFilterFactory
+func NewTrimFilter() Filter {
+	return NewAnalyzerComponent("solr.TrimFilterFactory")
+}
+
+// NewLengthFilter returns a solr.LengthFilterFactory
+func NewLengthFilter(min, max int) Filter {
+	return NewAnalyzerComponent("solr.LengthFilterFactory").
+		With("min", min).
+		With("max", max)
+}
+
+// NewLimitTokenCountFilter returns a solr.LimitTokenCountFilterFactory
+func NewLimitTokenCountFilter(maxTokenCount int) Filter {
+	return NewAnalyzerComponent("solr.LimitTokenCountFilterFactory").
+		With("maxTokenCount", maxTokenCount)
+}
+
+// NewPatternReplaceFilter returns a solr.PatternReplaceFilterFactory
+func NewPatternReplaceFilter(pattern, replacement string) Filter {
+	return NewAnalyzerComponent("solr.PatternReplaceFilterFactory").
+		With("pattern", pattern).
+		With("replacement", replacement)
+}
+
+// NewReversedWildcardFilter returns a solr.ReversedWildcardFilterFactory
+func NewReversedWildcardFilter() Filter {
+	return NewAnalyzerComponent("solr.ReversedWildcardFilterFactory")
+}
+
+// NewElisionFilter returns a solr.ElisionFilterFactory with the articles file
+func NewElisionFilter(articles string) Filter {
+	return NewAnalyzerComponent("solr.ElisionFilterFactory").
+		With("articles", articles)
+}
+
+// NewHyphenatedWordsFilter returns a solr.HyphenatedWordsFilterFactory
+func NewHyphenatedWordsFilter() Filter {
+	return NewAnalyzerComponent("solr.HyphenatedWordsFilterFactory")
+}
+
+// NewTypeTokenFilter returns a solr.TypeTokenFilterFactory with the types file
+func NewTypeTokenFilter(types string) Filter {
+	return NewAnalyzerComponent("solr.TypeTokenFilterFactory").
+		With("types", types)
+}
+
+// NewDelimitedPayloadTokenFilter returns a solr.DelimitedPayloadTokenFilterFactory
+// with the encoder i.e. "float", "integer" or "identity"
+func NewDelimitedPayloadTokenFilter(encoder string) Filter {
+	return NewAnalyzerComponent("solr.DelimitedPayloadTokenFilterFactory").
+		With("encoder", encoder)
+}
+
+// NewPhoneticFilter returns a solr.PhoneticFilterFactory with the encoder e.g. "DoubleMetaphone"
+func NewPhoneticFilter(encoder string) Filter {
+	return NewAnalyzerComponent("solr.PhoneticFilterFactory").
+		With("encoder", encoder)
+}
+
+// NewDoubleMetaphoneFilter returns a solr.DoubleMetaphoneFilterFactory
+func NewDoubleMetaphoneFilter() Filter {
+	return NewAnalyzerComponent("solr.DoubleMetaphoneFilterFactory")
+}
+
+// NewBeiderMorseFilter returns a solr.BeiderMorseFilterFactory
+func NewBeiderMorseFilter() Filter {
+	return NewAnalyzerComponent("solr.BeiderMorseFilterFactory")
+}
+
+// NewCJKBigramFilter returns a solr.CJKBigramFilterFactory
+func NewCJKBigramFilter() Filter {
+	return NewAnalyzerComponent("solr.CJKBigramFilterFactory")
+}
+
+// NewCJKWidthFilter returns a solr.CJKWidthFilterFactory
+func NewCJKWidthFilter() Filter {
+	return NewAnalyzerComponent("solr.CJKWidthFilterFactory")
+}
+
+// NewDecimalDigitFilter returns a solr.DecimalDigitFilterFactory
+func NewDecimalDigitFilter() Filter {
+	return NewAnalyzerComponent("solr.DecimalDigitFilterFactory")
+}
+
+// NewMinHashFilter returns a solr.MinHashFilterFactory
+func NewMinHashFilter(hashCount, bucketCount, hashSetSize int) Filter {
+	return NewAnalyzerComponent("solr.MinHashFilterFactory").
+		With("hashCount", hashCount).
+		With("bucketCount", bucketCount).
+		With("hashSetSize", hashSetSize)
+}
+
+// NewDictionaryCompoundWordTokenFilter returns a solr.DictionaryCompoundWordTokenFilterFactory
+func NewDictionaryCompoundWordTokenFilter(dictionary string, minWordSize int) Filter {
+	return NewAnalyzerComponent("solr.DictionaryCompoundWordTokenFilterFactory").
+		With("dictionary", dictionary).
+		With("minWordSize", minWordSize)
+}
@@ -0,0 +1,122 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestAnalyzerComponent(t *testing.T) {
+	t.Run("with", func(t *testing.T) {
+		base := solr.NewShingleFilter(2, 3)
+		filter := base.With("outputUnigrams", false).With("maxShingleSize", 4)
+
+		assert.Equal(t, []solr.AnalyzerParam{
+			{Name: "minShingleSize", Value: 2},
+			{Name: "maxShingleSize", Value: 4},
+			{Name: "outputUnigrams", Value: false},
+		}, filter.Params)
+
+		// the original component is not modified
+		maxShingleSize, ok := base.Param("maxShingleSize")
+		assert.True(t, ok)
+		assert.Equal(t, 3, maxShingleSize)
+
+		_, ok = base.Param("outputUnigrams")
+		assert.False(t, ok)
+	})
+
+	t.Run("marshal", func(t *testing.T) {
+		var tests = []struct {
+			component solr.AnalyzerComponent
+			expected  string
+		}{
+			{
+				solr.NewEdgeNGramFilter(0, 20),
+				`{"class":"solr.EdgeNGramFilterFactory","minGramSize":0,"maxGramSize":20}`,
+			},
+			{
+				solr.NewPathHierarchyTokenizer("/"),
+				`{"class":"solr.PathHierarchyTokenizerFactory","delimiter":"/"}`,
+			},
+			{
+				solr.NewStopFilter("stopwords.txt").With("ignoreCase", "true"),
+				`{"class":"solr.StopFilterFactory","words":"stopwords.txt","ignoreCase":"true"}`,
+			},
+			{
+				solr.AnalyzerComponent{Name: "lowercase"},
+				`{"name":"lowercase"}`,
+			},
+			{
+				solr.AnalyzerComponent{Class: "solr.TrimFilterFactory", Params: []solr.AnalyzerParam{
+					{Name: "class", Value: "ignored"},
+					{Name: "updateOffsets", Value: true},
+				}},
+				`{"class":"solr.TrimFilterFactory","updateOffsets":true}`,
+			},
+		}
+
+		for _, test := range tests {
+			b, err := json.Marshal(test.component)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(b))
+		}
+
+		_, err := json.Marshal(solr.NewLowerCaseFilter().With("x", make(chan int)))
+		assert.Error(t, err)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var filter solr.Filter
+		err := json.Unmarshal([]byte(`{"class":"solr.NGramFilterFactory","minGramSize":"2","maxGramSize":"15","preserveOriginal":true}`), &filter)
+		require.NoError(t, err)
+
+		expect := solr.NewAnalyzerComponent("solr.NGramFilterFactory").
+			With("minGramSize", "2").
+			With("maxGramSize", "15").
+			With("preserveOriginal", true)
+		assert.Equal(t, expect, filter)
+
+		err = json.Unmarshal([]byte(`{"name":"lowercase"}`), &filter)
+		require.NoError(t, err)
+		assert.Equal(t, solr.AnalyzerComponent{Name: "lowercase"}, filter)
+
+		err = json.Unmarshal([]byte(`["solr.LowerCaseFilterFactory"]`), &filter)
+		assert.Error(t, err)
+	})
+}
+
+func TestAnalyzer(t *testing.T) {
+	analyzer := solr.NewAnalyzer(
+		solr.NewWhitespaceTokenizer(),
+		solr.NewLowerCaseFilter(),
+		solr.NewSynonymGraphFilter("synonyms.txt").With("expand", "true"),
+		solr.NewFlattenGraphFilter(),
+	).WithCharFilters(solr.NewHTMLStripCharFilter())
+
+	b, err := json.Marshal(analyzer)
+	require.NoError(t, err)
+
+	expect := `{
+		"tokenizer":{"class":"solr.WhitespaceTokenizerFactory"},
+		"filters":[
+			{"class":"solr.LowerCaseFilterFactory"},
+			{"class":"solr.SynonymGraphFilterFactory","synonyms":"synonyms.txt","expand":"true"},
+			{"class":"solr.FlattenGraphFilterFactory"}
+		],
+		"charFilters":[{"class":"solr.HTMLStripCharFilterFactory"}]
+	}`
+	assert.JSONEq(t, expect, string(b))
+
+	var decoded solr.Analyzer
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, *analyzer, decoded)
+
+	b, err = json.Marshal(solr.Analyzer{Class: "org.apache.lucene.analysis.core.WhitespaceAnalyzer"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"class":"org.apache.lucene.analysis.core.WhitespaceAnalyzer"}`, string(b))
+}
@@ -0,0 +1,91 @@
+package solr
+
+// VersionField is the field used for optimistic concurrency
+const VersionField = "_version_"
+
+// AtomicUpdate is the atomic update document builder.
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#atomic-updates
+type AtomicUpdate struct {
+	uniqueKey string
+	id        interface{}
+	version   *int64
+	fields    map[string]M
+}
+
+// NewAtomicUpdate returns a new AtomicUpdate for the document with the given id
+func NewAtomicUpdate(id interface{}) *AtomicUpdate {
+	return &AtomicUpdate{
+		uniqueKey: "id",
+		id:        id,
+		fields:    map[string]M{},
+	}
+}
+
+// WithUniqueKey overrides the unique key field, default is "id"
+func (a *AtomicUpdate) WithUniqueKey(uniqueKey string) *AtomicUpdate {
+	a.uniqueKey = uniqueKey
+	return a
+}
+
+// Set sets or replaces the field value, a nil value removes the field
+func (a *AtomicUpdate) Set(field string, value interface{}) *AtomicUpdate {
+	return a.modify(field, "set", value)
+}
+
+// Add adds the values to a multi-valued field
+func (a *AtomicUpdate) Add(field string, values ...interface{}) *AtomicUpdate {
+	return a.modify(field, "add", values)
+}
+
+// AddDistinct adds the values to a multi-valued field only if not already present
+func (a *AtomicUpdate) AddDistinct(field string, values ...interface{}) *AtomicUpdate {
+	return a.modify(field, "add-distinct", values)
+}
+
+// Remove removes all occurrences of the values from a multi-valued field
+func (a *AtomicUpdate) Remove(field string, values ...interface{}) *AtomicUpdate {
+	return a.modify(field, "remove", values)
+}
+
+// RemoveRegex removes all occurrences of the values matching the patterns from a multi-valued field
+func (a *AtomicUpdate) RemoveRegex(field string, patterns ...string) *AtomicUpdate {
+	return a.modify(field, "removeregex", patterns)
+}
+
+// Inc increments a numeric field by the given amount, use a negative amount to decrement
+func (a *AtomicUpdate) Inc(field string, amount interface{}) *AtomicUpdate {
+	return a.modify(field, "inc", amount)
+}
+
+// Version sets the expected document version for optimistic concurrency.
+// A version mismatch is returned as a *SolrError that satisfies IsConflict.
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#optimistic-concurrency
+func (a *AtomicUpdate) Version(version int64) *AtomicUpdate {
+	a.version = &version
+	return a
+}
+
+func (a *AtomicUpdate) modify(field, op string, value interface{}) *AtomicUpdate {
+	if _, ok := a.fields[field]; !ok {
+		a.fields[field] = M{}
+	}
+	a.fields[field][op] = value
+	return a
+}
+
+// BuildDocument builds the atomic update document
+func (a *AtomicUpdate) BuildDocument() M {
+	doc := M{a.uniqueKey: a.id}
+
+	if a.version != nil {
+		doc[VersionField] = *a.version
+	}
+
+	for field, ops := range a.fields {
+		doc[field] = ops
+	}
+
+	return doc
+}
@@ -0,0 +1,90 @@
+package solr_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestAtomicUpdate(t *testing.T) {
+	got := solr.NewAtomicUpdate("p1").
+		Set("name", "Solr in Action").
+		Set("discontinued_b", nil).
+		Add("tags_ss", "search", "lucene").
+		AddDistinct("cat_ss", "books").
+		Remove("tags_ss", "old").
+		RemoveRegex("colors_ss", "^bl.*").
+		Inc("stock_i", -1).
+		Version(1234).
+		BuildDocument()
+
+	expect := solr.M{
+		"id":             "p1",
+		"_version_":      int64(1234),
+		"name":           solr.M{"set": "Solr in Action"},
+		"discontinued_b": solr.M{"set": nil},
+		"tags_ss": solr.M{
+			"add":    []interface{}{"search", "lucene"},
+			"remove": []interface{}{"old"},
+		},
+		"cat_ss":    solr.M{"add-distinct": []interface{}{"books"}},
+		"colors_ss": solr.M{"removeregex": []string{"^bl.*"}},
+		"stock_i":   solr.M{"inc": -1},
+	}
+	assert.Equal(t, expect, got)
+
+	got = solr.NewAtomicUpdate(1).WithUniqueKey("sku").Set("name", "x").BuildDocument()
+	assert.Equal(t, solr.M{"sku": 1, "name": solr.M{"set": "x"}}, got)
+}
+
+func TestAtomicUpdateVersionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	// above 2^53, can't be represented exactly as float64
+	const version = int64(1712345678901234567)
+
+	var updateBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.URL.Path {
+		case "/solr/products/query":
+			_, _ = w.Write([]byte(`{"response": {"numFound": 1, "docs": [
+				{"id": "p1", "_version_": 1712345678901234567}
+			]}}`))
+		case "/solr/products/update":
+			b, _ := io.ReadAll(r.Body)
+			updateBody = string(b)
+			_, _ = w.Write([]byte(`{"responseHeader": {"status": 0}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	type versioned struct {
+		ID      string `solr:"id"`
+		Version int64  `solr:"_version_"`
+	}
+
+	client := solr.NewJSONClient(ts.URL)
+	resp, err := solr.QueryInto[versioned](ctx, client, "products", solr.NewQuery("id:p1"))
+	require.NoError(t, err)
+	require.Len(t, resp.Response.Documents, 1)
+
+	doc := resp.Response.Documents[0]
+	assert.Equal(t, version, doc.Version)
+
+	_, err = client.AddDocuments(ctx, "products",
+		solr.NewAtomicUpdate(doc.ID).Set("name", "x").Version(doc.Version))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"p1","_version_":1712345678901234567,"name":{"set":"x"}}]`, updateBody)
+	// JSONEq compares the numbers as float64
+	assert.Contains(t, updateBody, `"_version_":1712345678901234567`)
+}
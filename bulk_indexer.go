@@ -0,0 +1,412 @@
+package solr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBulkIndexerClosed is returned when adding documents to a closed BulkIndexer
+var ErrBulkIndexerClosed = errors.New("bulk indexer is closed")
+
+// BulkIndexer indexes documents in batches using concurrent workers. Documents are
+// queued via Add and each worker flushes its batch when it reaches the batch size,
+// the batch byte size or when the flush interval elapses. Add blocks when the
+// queue is full so that the producers are slowed down to the indexing rate.
+//
+// Failed batches are retried with exponential backoff, the result of each batch
+// is reported through the success and failure callbacks.
+type BulkIndexer struct {
+	client     Client
+	collection string
+
+	numWorkers    int
+	batchSize     int
+	batchBytes    int
+	flushInterval time.Duration
+	maxRetries    int
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	onSuccess     func(BulkBatchResult)
+	onFailure     func(BulkBatchResult, error)
+
+	// mu guards closed, the queue is closed once the pending Adds returned
+	mu     sync.RWMutex
+	closed bool
+	queue  chan json.RawMessage
+	// done is closed by Close to unblock the pending Adds
+	done chan struct{}
+	// adding is the pending Adds
+	adding sync.WaitGroup
+
+	startOnce sync.Once
+	wg        sync.WaitGroup
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	stats bulkIndexerStats
+}
+
+// BulkBatchResult is the result of indexing a batch
+type BulkBatchResult struct {
+	// Documents is the encoded documents in the batch
+	Documents []json.RawMessage
+	// Bytes is the size of the request body
+	Bytes int
+	// Attempts is the number of update requests sent
+	Attempts int
+	// Duration is the time it took to flush the batch including retries
+	Duration time.Duration
+	// Response is the response of the last successful update
+	Response *UpdateResponse
+}
+
+// BulkIndexerStats is the BulkIndexer stats
+type BulkIndexerStats struct {
+	// NumAdded is the number of documents added to the queue
+	NumAdded uint64
+	// NumIndexed is the number of documents successfully indexed
+	NumIndexed uint64
+	// NumFailed is the number of documents that failed to index
+	NumFailed uint64
+	// NumBatches is the number of batches successfully indexed
+	NumBatches uint64
+	// NumFailedBatches is the number of batches that failed after all retries
+	NumFailedBatches uint64
+	// NumRetries is the number of update requests retried
+	NumRetries uint64
+	// NumBytes is the total size of the flushed batches
+	NumBytes uint64
+	// AvgFlushLatency is the average time it took to flush a batch
+	AvgFlushLatency time.Duration
+	// MaxFlushLatency is the longest time it took to flush a batch
+	MaxFlushLatency time.Duration
+}
+
+type bulkIndexerStats struct {
+	numAdded,
+	numIndexed,
+	numFailed,
+	numBatches,
+	numFailedBatches,
+	numRetries,
+	numBytes,
+	totalFlushNanos,
+	maxFlushNanos atomic.Uint64
+}
+
+// NewBulkIndexer returns a new BulkIndexer for the collection. The workers are
+// started on the first Add, call Close to flush the remaining documents.
+func NewBulkIndexer(client Client, collection string) *BulkIndexer {
+	return &BulkIndexer{
+		client:        client,
+		collection:    collection,
+		numWorkers:    4,
+		batchSize:     1000,
+		batchBytes:    5 << 20,
+		flushInterval: 5 * time.Second,
+		maxRetries:    3,
+		minBackoff:    100 * time.Millisecond,
+		maxBackoff:    10 * time.Second,
+	}
+}
+
+// WithNumWorkers overrides the number of concurrent workers, default is 4
+func (bi *BulkIndexer) WithNumWorkers(numWorkers int) *BulkIndexer {
+	bi.numWorkers = numWorkers
+	return bi
+}
+
+// WithBatchSize overrides the maximum number of documents in a batch, default is 1000
+func (bi *BulkIndexer) WithBatchSize(batchSize int) *BulkIndexer {
+	bi.batchSize = batchSize
+	return bi
+}
+
+// WithBatchBytes overrides the maximum size of a batch in bytes, default is 5MB.
+// A document that is larger than the maximum size is sent in its own batch.
+func (bi *BulkIndexer) WithBatchBytes(batchBytes int) *BulkIndexer {
+	bi.batchBytes = batchBytes
+	return bi
+}
+
+// WithFlushInterval overrides the interval at which partial batches are flushed, default is 5s
+func (bi *BulkIndexer) WithFlushInterval(flushInterval time.Duration) *BulkIndexer {
+	bi.flushInterval = flushInterval
+	return bi
+}
+
+// WithMaxRetries overrides the maximum number of retries of a failed batch, default is 3
+func (bi *BulkIndexer) WithMaxRetries(maxRetries int) *BulkIndexer {
+	bi.maxRetries = maxRetries
+	return bi
+}
+
+// WithBackoff overrides the minimum and maximum backoff between retries, default is 100ms and 10s
+func (bi *BulkIndexer) WithBackoff(minBackoff, maxBackoff time.Duration) *BulkIndexer {
+	bi.minBackoff = minBackoff
+	bi.maxBackoff = maxBackoff
+	return bi
+}
+
+// WithSuccessCallback sets the function called after a batch is indexed.
+// The callback is called from the worker goroutines.
+func (bi *BulkIndexer) WithSuccessCallback(fn func(BulkBatchResult)) *BulkIndexer {
+	bi.onSuccess = fn
+	return bi
+}
+
+// WithFailureCallback sets the function called when a batch fails after all retries.
+// The callback is called from the worker goroutines.
+func (bi *BulkIndexer) WithFailureCallback(fn func(BulkBatchResult, error)) *BulkIndexer {
+	bi.onFailure = fn
+	return bi
+}
+
+// Add encodes the document using EncodeDocument and adds it to the queue.
+// It blocks until the document is queued, the context is done or the BulkIndexer is closed.
+func (bi *BulkIndexer) Add(ctx context.Context, doc interface{}) error {
+	encoded, err := EncodeDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return wrapErr(err, "marshal document")
+	}
+
+	bi.startOnce.Do(bi.start)
+
+	bi.mu.RLock()
+	if bi.closed {
+		bi.mu.RUnlock()
+		return ErrBulkIndexerClosed
+	}
+	bi.adding.Add(1)
+	bi.mu.RUnlock()
+	defer bi.adding.Done()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-bi.done:
+		return ErrBulkIndexerClosed
+	case bi.queue <- b:
+		bi.stats.numAdded.Add(1)
+		return nil
+	}
+}
+
+// Close stops accepting documents and waits for the queued documents to be flushed.
+// The Adds blocked on a full queue return ErrBulkIndexerClosed. If the context is
+// done before the documents are flushed, the in-flight requests are cancelled.
+func (bi *BulkIndexer) Close(ctx context.Context) error {
+	bi.startOnce.Do(bi.start)
+
+	bi.mu.Lock()
+	closing := !bi.closed
+	if closing {
+		bi.closed = true
+		close(bi.done)
+	}
+	bi.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		if closing {
+			// no more sends once the pending Adds returned
+			bi.adding.Wait()
+			close(bi.queue)
+		}
+		bi.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		bi.cancel()
+		return nil
+	case <-ctx.Done():
+		bi.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Stats returns the BulkIndexer stats
+func (bi *BulkIndexer) Stats() BulkIndexerStats {
+	numBatches := bi.stats.numBatches.Load()
+	numFailedBatches := bi.stats.numFailedBatches.Load()
+
+	var avgFlushLatency time.Duration
+	if flushed := numBatches + numFailedBatches; flushed > 0 {
+		avgFlushLatency = time.Duration(bi.stats.totalFlushNanos.Load() / flushed)
+	}
+
+	return BulkIndexerStats{
+		NumAdded:         bi.stats.numAdded.Load(),
+		NumIndexed:       bi.stats.numIndexed.Load(),
+		NumFailed:        bi.stats.numFailed.Load(),
+		NumBatches:       numBatches,
+		NumFailedBatches: numFailedBatches,
+		NumRetries:       bi.stats.numRetries.Load(),
+		NumBytes:         bi.stats.numBytes.Load(),
+		AvgFlushLatency:  avgFlushLatency,
+		MaxFlushLatency:  time.Duration(bi.stats.maxFlushNanos.Load()),
+	}
+}
+
+// start starts the workers
+func (bi *BulkIndexer) start() {
+	numWorkers := bi.numWorkers
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	bi.ctx, bi.cancel = context.WithCancel(context.Background())
+	bi.queue = make(chan json.RawMessage, numWorkers)
+	bi.done = make(chan struct{})
+
+	bi.wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go bi.worker()
+	}
+}
+
+// worker batches the queued documents and flushes them
+func (bi *BulkIndexer) worker() {
+	defer bi.wg.Done()
+
+	var (
+		batch     []json.RawMessage
+		batchSize int
+	)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		bi.flush(batch)
+		batch, batchSize = nil, 0
+	}
+
+	var tick <-chan time.Time
+	if bi.flushInterval > 0 {
+		ticker := time.NewTicker(bi.flushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case doc, ok := <-bi.queue:
+			if !ok {
+				flush()
+				return
+			}
+
+			// keep the batch under the byte limit, accounting for the separators
+			if bi.batchBytes > 0 && batchSize+len(doc)+1 > bi.batchBytes {
+				flush()
+			}
+
+			batch = append(batch, doc)
+			batchSize += len(doc) + 1
+
+			if bi.batchSize > 0 && len(batch) >= bi.batchSize {
+				flush()
+			}
+		case <-tick:
+			flush()
+		}
+	}
+}
+
+// flush sends the batch, retrying on connection errors, 429 and 5xx responses
+func (bi *BulkIndexer) flush(batch []json.RawMessage) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('[')
+	for i, doc := range batch {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(doc)
+	}
+	buf.WriteByte(']')
+	body := buf.Bytes()
+
+	result := BulkBatchResult{Documents: batch, Bytes: len(body)}
+
+	start := time.Now()
+	var err error
+	for attempt := 0; ; attempt++ {
+		result.Attempts++
+		result.Response, err = bi.client.Update(bi.ctx, bi.collection, JSON, bytes.NewReader(body))
+		if err == nil || attempt >= bi.maxRetries || !isRetryableError(bi.ctx, err) {
+			break
+		}
+
+		bi.stats.numRetries.Add(1)
+
+		timer := time.NewTimer(exponentialBackoff(bi.minBackoff, bi.maxBackoff, attempt))
+		select {
+		case <-bi.ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+	result.Duration = time.Since(start)
+
+	bi.recordFlush(result.Duration)
+	bi.stats.numBytes.Add(uint64(result.Bytes))
+
+	if err != nil {
+		bi.stats.numFailedBatches.Add(1)
+		bi.stats.numFailed.Add(uint64(len(batch)))
+		if bi.onFailure != nil {
+			bi.onFailure(result, err)
+		}
+		return
+	}
+
+	bi.stats.numBatches.Add(1)
+	bi.stats.numIndexed.Add(uint64(len(batch)))
+	if bi.onSuccess != nil {
+		bi.onSuccess(result)
+	}
+}
+
+// recordFlush records the flush latency
+func (bi *BulkIndexer) recordFlush(d time.Duration) {
+	nanos := uint64(d)
+	bi.stats.totalFlushNanos.Add(nanos)
+	for {
+		maxNanos := bi.stats.maxFlushNanos.Load()
+		if nanos <= maxNanos || bi.stats.maxFlushNanos.CompareAndSwap(maxNanos, nanos) {
+			return
+		}
+	}
+}
+
+// isRetryableError returns true if the update can be retried i.e. connection
+// errors, 429 and 5xx responses
+func isRetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var solrErr *SolrError
+	if errors.As(err, &solrErr) {
+		return solrErr.StatusCode == http.StatusTooManyRequests ||
+			solrErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
@@ -0,0 +1,243 @@
+package solr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// newBulkServer returns a test server that records the size of each batch.
+// The first failures requests respond with the given status code.
+func newBulkServer(t *testing.T, failures int32, statusCode int) (*httptest.Server, func() []int) {
+	var (
+		mu      sync.Mutex
+		batches []int
+		calls   int32
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var docs []solr.M
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&docs))
+
+		w.Header().Set("content-type", "application/json")
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(`{"error":{"msg":"failed","code":0}}`))
+			return
+		}
+
+		mu.Lock()
+		batches = append(batches, len(docs))
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"responseHeader":{"status":0}}`))
+	}))
+
+	return ts, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int{}, batches...)
+	}
+}
+
+func TestBulkIndexer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("batch size", func(t *testing.T) {
+		ts, batches := newBulkServer(t, 0, 0)
+		defer ts.Close()
+
+		var succeeded int32
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithNumWorkers(1).
+			WithBatchSize(10).
+			WithFlushInterval(time.Hour).
+			WithSuccessCallback(func(result solr.BulkBatchResult) {
+				atomic.AddInt32(&succeeded, int32(len(result.Documents)))
+				assert.Equal(t, 1, result.Attempts)
+			})
+
+		for i := 0; i < 25; i++ {
+			err := bi.Add(ctx, solr.M{"id": i})
+			require.NoError(t, err)
+		}
+
+		err := bi.Close(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{10, 10, 5}, batches())
+		assert.Equal(t, int32(25), atomic.LoadInt32(&succeeded))
+
+		stats := bi.Stats()
+		assert.Equal(t, uint64(25), stats.NumAdded)
+		assert.Equal(t, uint64(25), stats.NumIndexed)
+		assert.Equal(t, uint64(3), stats.NumBatches)
+		assert.Zero(t, stats.NumFailed)
+		assert.NotZero(t, stats.NumBytes)
+		assert.NotZero(t, stats.MaxFlushLatency)
+		assert.LessOrEqual(t, stats.AvgFlushLatency, stats.MaxFlushLatency)
+
+		err = bi.Add(ctx, solr.M{"id": 26})
+		assert.ErrorIs(t, err, solr.ErrBulkIndexerClosed)
+
+		// closing again is a no-op
+		assert.NoError(t, bi.Close(ctx))
+	})
+
+	t.Run("batch bytes", func(t *testing.T) {
+		ts, batches := newBulkServer(t, 0, 0)
+		defer ts.Close()
+
+		// each document is 9 bytes e.g. {"id":1} plus a separator
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithNumWorkers(1).
+			WithBatchBytes(30).
+			WithFlushInterval(time.Hour)
+
+		for i := 0; i < 7; i++ {
+			err := bi.Add(ctx, solr.M{"id": i})
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, bi.Close(ctx))
+		assert.Equal(t, []int{3, 3, 1}, batches())
+	})
+
+	t.Run("flush interval", func(t *testing.T) {
+		ts, batches := newBulkServer(t, 0, 0)
+		defer ts.Close()
+
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithFlushInterval(10 * time.Millisecond)
+		defer bi.Close(ctx)
+
+		err := bi.Add(ctx, solr.M{"id": 1})
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return len(batches()) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		ts, batches := newBulkServer(t, 2, http.StatusServiceUnavailable)
+		defer ts.Close()
+
+		var attempts int
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithNumWorkers(1).
+			WithBackoff(time.Millisecond, 10*time.Millisecond).
+			WithSuccessCallback(func(result solr.BulkBatchResult) {
+				attempts = result.Attempts
+			})
+
+		err := bi.Add(ctx, solr.M{"id": 1})
+		require.NoError(t, err)
+		require.NoError(t, bi.Close(ctx))
+
+		assert.Equal(t, []int{1}, batches())
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, uint64(2), bi.Stats().NumRetries)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		ts, batches := newBulkServer(t, 1, http.StatusBadRequest)
+		defer ts.Close()
+
+		var failed []error
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithNumWorkers(1).
+			WithBackoff(time.Millisecond, 10*time.Millisecond).
+			WithFailureCallback(func(result solr.BulkBatchResult, err error) {
+				assert.Len(t, result.Documents, 2)
+				assert.Equal(t, 1, result.Attempts)
+				failed = append(failed, err)
+			})
+
+		for i := 0; i < 2; i++ {
+			err := bi.Add(ctx, solr.M{"id": i})
+			require.NoError(t, err)
+		}
+		require.NoError(t, bi.Close(ctx))
+
+		assert.Empty(t, batches())
+		require.Len(t, failed, 1)
+		assert.True(t, solr.IsBadRequest(failed[0]))
+
+		stats := bi.Stats()
+		assert.Equal(t, uint64(2), stats.NumFailed)
+		assert.Equal(t, uint64(1), stats.NumFailedBatches)
+		assert.Zero(t, stats.NumRetries)
+	})
+
+	t.Run("close timeout", func(t *testing.T) {
+		ts, _ := newBulkServer(t, 1<<30, http.StatusServiceUnavailable)
+		defer ts.Close()
+
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithMaxRetries(1<<20).
+			WithBackoff(time.Millisecond, time.Millisecond)
+
+		err := bi.Add(ctx, solr.M{"id": 1})
+		require.NoError(t, err)
+
+		closeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		err = bi.Close(closeCtx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, uint64(1), bi.Stats().NumFailed)
+	})
+
+	t.Run("close with a blocked add", func(t *testing.T) {
+		release := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"responseHeader":{"status":0}}`))
+		}))
+		defer ts.Close()
+		defer close(release)
+
+		bi := solr.NewBulkIndexer(solr.NewJSONClient(ts.URL), "products").
+			WithNumWorkers(1).
+			WithBatchSize(1).
+			WithMaxRetries(0)
+
+		// the first document is being flushed and the second fills the queue
+		require.NoError(t, bi.Add(ctx, solr.M{"id": 1}))
+		require.NoError(t, bi.Add(ctx, solr.M{"id": 2}))
+
+		added := make(chan error, 1)
+		go func() {
+			added <- bi.Add(ctx, solr.M{"id": 3})
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		closeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		err := bi.Close(closeCtx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.True(t, errors.Is(<-added, solr.ErrBulkIndexerClosed))
+		assert.Equal(t, uint64(2), bi.Stats().NumAdded)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		bi := solr.NewBulkIndexer(solr.NewJSONClient("http://localhost"), "products")
+		err := bi.Add(ctx, "not a document")
+		assert.Error(t, err)
+		assert.NoError(t, bi.Close(ctx))
+	})
+}
@@ -6,7 +6,7 @@ import (
 )
 
 // Client is an interface for interacting with Solr APIs
-// (Collections, Core Admin, Query, Update, Schema, Config and Suggester)
+// (Collections, Core Admin, Query, Update, Schema, Config, Managed Resources and Suggester)
 type Client interface {
 	// Collections Management API
 	// Status, Create, Delete, Reload, Rename, Modify, List
@@ -18,11 +18,17 @@ type Client interface {
 	// DeleteCollection deletes a collection.
 	// Refer to https://solr.apache.org/guide/8_8/collection-management.html#delete
 	DeleteCollection(context.Context, *CollectionParams) error
+	// ClusterStatus returns the status of the SolrCloud cluster.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/cluster-node-management.html#clusterstatus
+	ClusterStatus(context.Context) (*ClusterStatusResponse, error)
+	// ReloadCollection reloads a collection.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/collection-management.html#reload
+	ReloadCollection(context.Context, *CollectionParams) error
 
 	// // https://solr.apache.org/guide/8_8/collection-management.html#colstatus
 	// CollectionStatus(context.Context, *CollectionParams)
-	// // https://solr.apache.org/guide/8_8/collection-management.html#reload
-	// ReloadCollection(context.Context, *CollectionParams)
 	// // https://solr.apache.org/guide/8_8/collection-management.html#modifycollection
 	// ModifyCollection(context.Context, *CollectionParams)
 	// // https://solr.apache.org/guide/8_8/collection-management.html#rename
@@ -45,8 +51,10 @@ type Client interface {
 	//
 	// Refer to https://solr.apache.org/guide/8_8/coreadmin-api.html#coreadmin-unload
 	UnloadCore(context.Context, *CoreParams) error
-	// // https://solr.apache.org/guide/8_8/coreadmin-api.html#coreadmin-reload
-	// ReloadCore(context.Context, *CoreParams)
+	// ReloadCore reloads a core.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/coreadmin-api.html#coreadmin-reload
+	ReloadCore(context.Context, *CoreParams) error
 	// // https://solr.apache.org/guide/8_8/coreadmin-api.html#coreadmin-rename
 	// RenameCore(context.Context, *CoreParams)
 	// ListCores(context.Context)
@@ -60,11 +68,90 @@ type Client interface {
 	//
 	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html
 	Update(ctx context.Context, collection string, ct MimeType, body io.Reader) (*UpdateResponse, error)
-	// Commit commits the last update
-	Commit(ctx context.Context, collection string) error
+	// AddDocuments encodes and adds the documents (structs, maps or atomic updates) to the index.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#adding-multiple-json-documents
+	AddDocuments(ctx context.Context, collection string, docs ...interface{}) (*UpdateResponse, error)
+	// Commit commits the last update.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#commit-and-optimize-during-updates
+	Commit(ctx context.Context, collection string, opts ...*CommitOptions) error
+	// DeleteByIDs deletes the documents with the given ids.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#sending-json-update-commands
+	DeleteByIDs(ctx context.Context, collection string, ids []string, opts ...*CommitOptions) (*UpdateResponse, error)
+	// DeleteByQuery deletes the documents matching the query.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#sending-json-update-commands
+	DeleteByQuery(ctx context.Context, collection string, query QueryParser, opts ...*CommitOptions) (*UpdateResponse, error)
+	// Optimize merges the index segments.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#commit-and-optimize-during-updates
+	Optimize(ctx context.Context, collection string, maxSegments int) error
+	// Rollback discards the uncommitted updates, not available in SolrCloud mode.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#rollback-operations
+	Rollback(ctx context.Context, collection string) error
+
+	// Stream sends a streaming expression and returns an iterator over the tuples.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/streaming-expressions.html
+	Stream(ctx context.Context, collection string, expr StreamExpression) (*TupleIterator, error)
+	// SQL sends a statement to the Parallel SQL interface and returns an iterator over the tuples.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/parallel-sql-interface.html
+	SQL(ctx context.Context, collection string, params *SQLParams) (*TupleIterator, error)
+	// Export streams the sorted result set of a query from the export handler.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/exporting-result-sets.html
+	Export(ctx context.Context, collection string, params *ExportParams) (*TupleIterator, error)
 
 	// Schema API
 
+	// GetSchema returns the whole schema.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#retrieve-the-entire-schema
+	GetSchema(ctx context.Context, collection string) (*Schema, error)
+	// GetSchemaXML returns the whole schema in the schema.xml format.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#retrieve-the-entire-schema
+	GetSchemaXML(ctx context.Context, collection string) ([]byte, error)
+	// GetFields returns the fields.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+	GetFields(ctx context.Context, collection string, opts ...*SchemaOptions) ([]Field, error)
+	// GetField returns the field, or the matching dynamic field rule if includeDynamic is true.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+	GetField(ctx context.Context, collection, name string, includeDynamic bool, opts ...*SchemaOptions) (*Field, error)
+	// GetDynamicFields returns the dynamic field rules.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-dynamic-fields
+	GetDynamicFields(ctx context.Context, collection string, opts ...*SchemaOptions) ([]Field, error)
+	// GetFieldTypes returns the field types.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-field-types
+	GetFieldTypes(ctx context.Context, collection string, opts ...*SchemaOptions) ([]FieldType, error)
+	// GetCopyFields returns the copy field rules.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-copy-fields
+	GetCopyFields(ctx context.Context, collection string) ([]CopyField, error)
+	// GetUniqueKey returns the unique key field name.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-uniquekey
+	GetUniqueKey(ctx context.Context, collection string) (string, error)
+	// GetSimilarity returns the global similarity.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-global-similarity
+	GetSimilarity(ctx context.Context, collection string) (M, error)
+	// GetSchemaVersion returns the schema version.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-the-schema-version
+	GetSchemaVersion(ctx context.Context, collection string) (float64, error)
+	// ModifySchema sends multiple Schema API commands in a single request.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#multiple-commands-in-a-single-post
+	ModifySchema(ctx context.Context, collection string, commands ...SchemaCommand) error
 	// AddFields adds new field definitions to the schema.
 	//
 	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-new-field
@@ -120,6 +207,42 @@ type Client interface {
 	//
 	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-common-properties
 	UnsetProperty(ctx context.Context, collection string, property CommonProperty) error
+	// SetUserProperties sets user-defined properties that can be used for ${...} substitutions in solrconfig.xml.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-user-defined-properties
+	SetUserProperties(ctx context.Context, collection string, properties M) error
+	// UnsetUserProperty removes a user-defined property set via SetUserProperties.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-user-defined-properties
+	UnsetUserProperty(ctx context.Context, collection, name string) error
+	// GetUserProperties returns the user-defined properties.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-user-defined-properties
+	GetUserProperties(ctx context.Context, collection string) (M, error)
+	// GetConfig returns the effective config or a single section of it if section is not empty.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/config-api.html#retrieving-the-config
+	GetConfig(ctx context.Context, collection, section string) (*ConfigResponse, error)
+	// GetConfigOverlay returns the changes made via the Config API.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/config-api.html#config-overlay
+	GetConfigOverlay(ctx context.Context, collection string) (*ConfigOverlayResponse, error)
+	// SetParams creates or overwrites the paramsets.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#setting-request-parameters
+	SetParams(ctx context.Context, collection string, paramSets ...ParamSet) error
+	// UpdateParams merges the params into the existing paramsets.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#setting-request-parameters
+	UpdateParams(ctx context.Context, collection string, paramSets ...ParamSet) error
+	// DeleteParams deletes the paramsets.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#setting-request-parameters
+	DeleteParams(ctx context.Context, collection string, names ...string) error
+	// GetParams returns the paramsets, or only the named paramsets if names is not empty.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html
+	GetParams(ctx context.Context, collection string, names ...string) ([]ParamSet, error)
 	// AddComponents adds a component (request handler, search component, init params, etc.) to configoverlay.json.
 	//
 	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
@@ -133,6 +256,57 @@ type Client interface {
 	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
 	DeleteComponents(ctx context.Context, collection string, component ...*Component) error
 
+	// Managed Resources API
+
+	// ListManagedResources returns the resources registered with the REST manager.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#restmanager-endpoint
+	ListManagedResources(ctx context.Context, collection string) ([]ManagedResource, error)
+	// GetStopwords returns the managed stopwords list.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+	GetStopwords(ctx context.Context, collection, name string) (*ManagedWordSet, error)
+	// HasStopword returns true if the word is in the managed stopwords list.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+	HasStopword(ctx context.Context, collection, name, word string) (bool, error)
+	// AddStopwords adds the words to the managed stopwords list.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+	AddStopwords(ctx context.Context, collection, name string, words ...string) error
+	// DeleteStopword removes the word from the managed stopwords list.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+	DeleteStopword(ctx context.Context, collection, name, word string) error
+	// SetStopwordsIgnoreCase sets the ignoreCase init arg of the managed stopwords list.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+	SetStopwordsIgnoreCase(ctx context.Context, collection, name string, ignoreCase bool) error
+	// GetSynonyms returns the managed synonym map.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+	GetSynonyms(ctx context.Context, collection, name string) (*ManagedSynonymMap, error)
+	// GetSynonym returns the synonyms of the term in the managed synonym map.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+	GetSynonym(ctx context.Context, collection, name, term string) ([]string, error)
+	// AddSynonyms adds the synonym mappings to the managed synonym map.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+	AddSynonyms(ctx context.Context, collection, name string, mappings map[string][]string) error
+	// DeleteSynonym removes the term from the managed synonym map.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+	DeleteSynonym(ctx context.Context, collection, name, term string) error
+	// ReplaceSynonyms replaces the whole managed synonym map with the mappings.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+	ReplaceSynonyms(ctx context.Context, collection, name string, mappings map[string][]string) error
+	// SetSynonymsIgnoreCase sets the ignoreCase init arg of the managed synonym map.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+	SetSynonymsIgnoreCase(ctx context.Context, collection, name string, ignoreCase bool) error
+
 	// Suggester API
 
 	// Suggest queries the suggest endpoint.
@@ -0,0 +1,563 @@
+package solr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CloudJSONClient is a SolrCloud-aware JSONClient. It discovers the live nodes and
+// the replicas of each collection from CLUSTERSTATUS, then sends queries to an
+// active replica and updates to a shard leader of the target collection.
+//
+// The cluster state is cached and refreshed periodically and whenever a node fails.
+type CloudJSONClient struct {
+	*JSONClient
+
+	// seeds is the base URLs used to discover the cluster
+	seeds []string
+	// reqSender is the request sender used to send requests to the nodes
+	reqSender RequestSender
+	// lb is the load-balancing request sender for the seed and live nodes
+	lb *LBRequestSender
+	// refreshInterval is the interval between cluster state refreshes
+	refreshInterval time.Duration
+	// uniqueKey is the uniqueKey field used for routing documents
+	uniqueKey string
+
+	// counter is the round-robin counter
+	counter atomic.Uint64
+	// refreshing is set while an asynchronous refresh is in progress
+	refreshing atomic.Bool
+
+	mu sync.RWMutex
+	// state is the cached cluster state
+	state *ClusterStatus
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+var _ Client = (*CloudJSONClient)(nil)
+
+// NewCloudJSONClient takes the base URLs of one or more SolrCloud
+// nodes and returns a new CloudJSONClient
+func NewCloudJSONClient(baseURLs ...string) *CloudJSONClient {
+	c := &CloudJSONClient{
+		seeds:           baseURLs,
+		refreshInterval: time.Minute,
+		uniqueKey:       "id",
+		done:            make(chan struct{}),
+	}
+	c.JSONClient = &JSONClient{reqSender: &cloudRequestSender{client: c}}
+
+	return c.WithRequestSender(NewDefaultRequestSender())
+}
+
+// WithRequestSender overrides the request sender used to send requests to the nodes
+func (c *CloudJSONClient) WithRequestSender(reqSender RequestSender) *CloudJSONClient {
+	if c.lb != nil {
+		c.lb.Close()
+	}
+
+	c.reqSender = reqSender
+	c.lb = NewLBRequestSender(reqSender, c.seeds...)
+	return c
+}
+
+// WithRefreshInterval overrides the interval between cluster state refreshes
+func (c *CloudJSONClient) WithRefreshInterval(refreshInterval time.Duration) *CloudJSONClient {
+	c.refreshInterval = refreshInterval
+	return c
+}
+
+// WithUniqueKey overrides the uniqueKey field used for routing documents, defaults to "id"
+func (c *CloudJSONClient) WithUniqueKey(uniqueKey string) *CloudJSONClient {
+	c.uniqueKey = uniqueKey
+	return c
+}
+
+// Refresh fetches the cluster status and updates the cached cluster state
+func (c *CloudJSONClient) Refresh(ctx context.Context) error {
+	resp, err := (&JSONClient{reqSender: c.lb}).ClusterStatus(ctx)
+	if err != nil {
+		return wrapErr(err, "cluster status")
+	}
+
+	if resp.Cluster == nil {
+		return fmt.Errorf("cluster status: empty cluster state")
+	}
+
+	c.mu.Lock()
+	c.state = resp.Cluster
+	c.mu.Unlock()
+
+	// include the live nodes when fetching the cluster status
+	c.lb.addNodes(liveNodeURLs(resp.Cluster)...)
+
+	return nil
+}
+
+// ClusterState returns the cached cluster state
+func (c *CloudJSONClient) ClusterState() *ClusterStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// Close stops refreshing the cluster state
+func (c *CloudJSONClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.lb.Close()
+	})
+}
+
+// Update sends the update to the shard leaders. A JSON array of documents is split
+// per shard using the document router of the collection and each batch is sent
+// directly to its shard leader. Other updates are sent to any of the leaders.
+//
+// The batches are sent in order of the shard names and the response headers are merged,
+// the status is the highest status and the QTime is the sum. If a batch fails, the
+// remaining batches are not sent and the error reports the number of batches indexed.
+// A batch is only resent if the leader couldn't be reached or rejected it as not the leader.
+//
+// Refer to https://solr.apache.org/guide/8_8/shards-and-indexing-data-in-solrcloud.html#document-routing
+func (c *CloudJSONClient) Update(ctx context.Context, collection string, mimeType MimeType, body io.Reader) (*UpdateResponse, error) {
+	if mimeType != JSON {
+		return c.JSONClient.Update(ctx, collection, mimeType, body)
+	}
+
+	c.startRefreshLoop()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, wrapErr(err, "read request body")
+	}
+
+	if c.ClusterState() == nil {
+		err = c.Refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	batches, ok := c.splitDocuments(collection, b)
+	if !ok {
+		return c.JSONClient.Update(ctx, collection, mimeType, bytes.NewReader(b))
+	}
+
+	// the response headers of the batches are merged into a single response
+	resp := &UpdateResponse{BaseResponse: &BaseResponse{Header: &ResponseHeader{}}}
+	for i, batch := range batches {
+		batchResp, err := c.updateLeader(ctx, collection, batch)
+		if err != nil {
+			return nil, wrapErr(err, fmt.Sprintf("update %s (%d of %d batches indexed)",
+				batch.shard, i, len(batches)))
+		}
+
+		if batchResp.BaseResponse == nil || batchResp.Header == nil {
+			continue
+		}
+
+		header := batchResp.Header
+		if header.Status > resp.Header.Status {
+			resp.Header.Status = header.Status
+		}
+		resp.Header.QTime += header.QTime
+		resp.Header.ZKConnected = header.ZKConnected
+	}
+
+	return resp, nil
+}
+
+// AddDocuments encodes the documents and sends them to the shard leaders
+func (c *CloudJSONClient) AddDocuments(ctx context.Context, collection string, docs ...interface{}) (*UpdateResponse, error) {
+	body, err := encodeDocuments(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Update(ctx, collection, JSON, body)
+}
+
+// shardBatch is a batch of documents for a shard leader
+type shardBatch struct {
+	shard  string
+	leader *ReplicaState
+	docs   []json.RawMessage
+}
+
+// splitDocuments splits the JSON array of documents into batches per shard leader.
+// It returns false if the documents cannot be routed on the client side.
+func (c *CloudJSONClient) splitDocuments(collection string, body []byte) ([]*shardBatch, bool) {
+	var docs []json.RawMessage
+	if json.Unmarshal(body, &docs) != nil || len(docs) == 0 {
+		return nil, false
+	}
+
+	state := c.ClusterState()
+	if aliased, ok := state.Aliases[collection]; ok {
+		collection = strings.Split(aliased, ",")[0]
+	}
+
+	coll, ok := state.Collections[collection]
+	if !ok {
+		return nil, false
+	}
+
+	routeField := c.uniqueKey
+	if coll.Router != nil && coll.Router.Field != "" {
+		routeField = coll.Router.Field
+	}
+
+	liveNodes := map[string]bool{}
+	for _, node := range state.LiveNodes {
+		liveNodes[node] = true
+	}
+
+	batches := map[string]*shardBatch{}
+	names := []string{}
+	for _, doc := range docs {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(doc, &fields) != nil {
+			return nil, false
+		}
+
+		routeValue, ok := rawString(fields[routeField])
+		if !ok {
+			return nil, false
+		}
+
+		shard, err := coll.Route(routeValue)
+		if err != nil {
+			return nil, false
+		}
+
+		batch, ok := batches[shard]
+		if !ok {
+			leader := shardLeader(coll.Shards[shard], liveNodes)
+			if leader == nil {
+				return nil, false
+			}
+
+			batch = &shardBatch{shard: shard, leader: leader}
+			batches[shard] = batch
+			names = append(names, shard)
+		}
+		batch.docs = append(batch.docs, doc)
+	}
+
+	sort.Strings(names)
+	result := make([]*shardBatch, 0, len(names))
+	for _, name := range names {
+		result = append(result, batches[name])
+	}
+
+	return result, true
+}
+
+// updateLeader sends the batch of documents directly to the shard leader
+func (c *CloudJSONClient) updateLeader(ctx context.Context, collection string, batch *shardBatch) (*UpdateResponse, error) {
+	buf := &bytes.Buffer{}
+	err := json.NewEncoder(buf).Encode(batch.docs)
+	if err != nil {
+		return nil, wrapErr(err, "encode request body")
+	}
+
+	urlStr := fmt.Sprintf("%s/%s/update", batch.leader.BaseURL, batch.leader.Core)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, JSON.String(), bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, wrapErr(err, "send request")
+		}
+
+		// the leader might have changed
+		c.refreshAsync()
+
+		// the leader might have applied the batch unless the connection couldn't be established
+		if !isDialError(err) {
+			return nil, wrapErr(err, "send request")
+		}
+
+		// let the cluster forward the batch
+		return c.JSONClient.Update(ctx, collection, JSON, buf)
+	}
+
+	var resp UpdateResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		var solrErr *SolrError
+		if errors.As(err, &solrErr) && solrErr.StatusCode >= http.StatusInternalServerError {
+			// the cached leader might be stale
+			c.refreshAsync()
+
+			// the batch was rejected, let the cluster forward it to the new leader
+			if isNotLeaderError(solrErr) {
+				return c.JSONClient.Update(ctx, collection, JSON, buf)
+			}
+		}
+
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// sendRequest sends the request to a replica of the target collection
+func (c *CloudJSONClient) sendRequest(ctx context.Context, httpMethod,
+	urlStr, contentType string, body io.Reader) (*http.Response, error) {
+	c.startRefreshLoop()
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, wrapErr(err, "parse url")
+	}
+
+	collection, handler := splitCollectionPath(u.Path)
+	if collection == "" {
+		// admin requests can be handled by any node
+		return c.lb.SendRequest(ctx, httpMethod, urlStr, contentType, body)
+	}
+
+	if c.ClusterState() == nil {
+		err = c.Refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	leadersOnly := strings.HasPrefix(handler, "update")
+	nodes := c.replicaURLs(collection, leadersOnly)
+	if len(nodes) == 0 {
+		// probably a new collection, let any node forward the request
+		c.refreshAsync()
+		return c.lb.SendRequest(ctx, httpMethod, urlStr, contentType, body)
+	}
+
+	newBody, err := bufferBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	start := int(c.counter.Add(1) % uint64(len(nodes)))
+	nodes = append(append([]string{}, nodes[start:]...), nodes[:start]...)
+
+	idempotent := isIdempotent(httpMethod, urlStr)
+	path := strings.TrimPrefix(u.RequestURI(), "/solr")
+
+	var (
+		httpResp *http.Response
+		lastErr  error
+	)
+	for i, node := range nodes {
+		httpResp, err = c.reqSender.SendRequest(ctx, httpMethod,
+			node+path, contentType, newBody())
+		if err == nil && httpResp.StatusCode < http.StatusInternalServerError {
+			return httpResp, nil
+		}
+
+		// the caller gave up, it's not the node's fault
+		if ctx.Err() != nil {
+			return httpResp, err
+		}
+
+		// the cluster state might have changed e.g. a new leader was elected
+		c.refreshAsync()
+
+		// the node might have applied a non-idempotent request,
+		// only retry it if the connection couldn't be established
+		if err == nil && !idempotent {
+			return httpResp, nil
+		}
+		if err != nil && !idempotent && !isDialError(err) {
+			return nil, err
+		}
+
+		lastErr = err
+		if httpResp != nil && i < len(nodes)-1 {
+			drainAndClose(httpResp)
+		}
+	}
+
+	return httpResp, lastErr
+}
+
+// replicaURLs returns the base URLs of the active replicas of the collection
+// that are on live nodes. If leadersOnly is set, only the shard leaders are returned.
+func (c *CloudJSONClient) replicaURLs(collection string, leadersOnly bool) []string {
+	state := c.ClusterState()
+	if state == nil {
+		return nil
+	}
+
+	// use the first collection of an alias
+	if aliased, ok := state.Aliases[collection]; ok {
+		collection = strings.Split(aliased, ",")[0]
+	}
+
+	coll, ok := state.Collections[collection]
+	if !ok {
+		return nil
+	}
+
+	liveNodes := map[string]bool{}
+	for _, node := range state.LiveNodes {
+		liveNodes[node] = true
+	}
+
+	seen := map[string]bool{}
+	urls := []string{}
+	for _, shard := range coll.Shards {
+		if shard.State != "active" {
+			continue
+		}
+
+		for _, replica := range shard.Replicas {
+			if replica.State != "active" || !liveNodes[replica.NodeName] ||
+				(leadersOnly && !replica.IsLeader()) || seen[replica.BaseURL] {
+				continue
+			}
+
+			seen[replica.BaseURL] = true
+			urls = append(urls, replica.BaseURL)
+		}
+	}
+	sort.Strings(urls)
+
+	return urls
+}
+
+// startRefreshLoop starts refreshing the cluster state periodically
+func (c *CloudJSONClient) startRefreshLoop() {
+	c.startOnce.Do(func() { go c.refreshLoop() })
+}
+
+// refreshLoop refreshes the cluster state until the client is closed
+func (c *CloudJSONClient) refreshLoop() {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.refreshInterval)
+		_ = c.Refresh(ctx)
+		cancel()
+	}
+}
+
+// refreshAsync refreshes the cluster state in the background
+func (c *CloudJSONClient) refreshAsync() {
+	if !c.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer c.refreshing.Store(false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.refreshInterval)
+		defer cancel()
+		_ = c.Refresh(ctx)
+	}()
+}
+
+// cloudRequestSender is the request sender of the embedded JSONClient
+type cloudRequestSender struct {
+	client *CloudJSONClient
+}
+
+var _ RequestSender = (*cloudRequestSender)(nil)
+
+// SendRequest sends the request to a replica of the target collection
+func (rs *cloudRequestSender) SendRequest(ctx context.Context, httpMethod,
+	urlStr, contentType string, body io.Reader) (*http.Response, error) {
+	return rs.client.sendRequest(ctx, httpMethod, urlStr, contentType, body)
+}
+
+// splitCollectionPath splits the request path into the collection and
+// the handler e.g. "/solr/products/query" into "products" and "query".
+// The collection is empty for admin requests.
+func splitCollectionPath(path string) (collection, handler string) {
+	if !strings.HasPrefix(path, "/solr/") {
+		return "", ""
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(path, "/solr/"), "/", 2)
+	if len(parts) < 2 || parts[0] == "admin" {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// shardLeader returns the active leader of the shard if it's on a live node
+func shardLeader(shard *ShardState, liveNodes map[string]bool) *ReplicaState {
+	for _, replica := range shard.Replicas {
+		if replica.IsLeader() && replica.State == "active" && liveNodes[replica.NodeName] {
+			return replica
+		}
+	}
+
+	return nil
+}
+
+// isNotLeaderError returns true if the update was rejected because
+// the replica is no longer the shard leader e.g. "ClusterState says
+// we are the leader, but locally we don't think so"
+func isNotLeaderError(solrErr *SolrError) bool {
+	return solrErr.StatusCode == http.StatusServiceUnavailable && solrErr.Err != nil &&
+		strings.Contains(strings.ToLower(solrErr.Err.Msg), "leader")
+}
+
+// rawString returns the string form of a raw JSON string or number
+func rawString(raw json.RawMessage) (string, bool) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s, true
+	}
+
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String(), true
+	}
+
+	return "", false
+}
+
+// liveNodeURLs returns the base URLs (without the /solr context path)
+// of the live nodes that are hosting at least one replica
+func liveNodeURLs(state *ClusterStatus) []string {
+	baseURLs := map[string]string{}
+	for _, coll := range state.Collections {
+		for _, shard := range coll.Shards {
+			for _, replica := range shard.Replicas {
+				baseURLs[replica.NodeName] = replica.BaseURL
+			}
+		}
+	}
+
+	urls := []string{}
+	for _, node := range state.LiveNodes {
+		if baseURL, ok := baseURLs[node]; ok {
+			urls = append(urls, strings.TrimSuffix(baseURL, "/solr"))
+		}
+	}
+
+	return urls
+}
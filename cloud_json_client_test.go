@@ -0,0 +1,412 @@
+package solr_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// testCluster is a fake SolrCloud cluster with two nodes
+type testCluster struct {
+	mu    sync.Mutex
+	hits  map[string][]string
+	nodes []*httptest.Server
+	down  map[int]bool
+	// notLeader rejects the updates sent to the cores of the node
+	notLeader map[int]bool
+	// dropConn reads the updates sent to the cores of the node then drops the connection
+	dropConn map[int]bool
+	// splitLeaders moves the leader of shard2 to the second node
+	splitLeaders bool
+
+	clusterStatusCalls int32
+}
+
+func newTestCluster() *testCluster {
+	tc := &testCluster{hits: map[string][]string{}, down: map[int]bool{}, notLeader: map[int]bool{},
+		dropConn: map[int]bool{}}
+	for i := 0; i < 2; i++ {
+		i := i
+		tc.nodes = append(tc.nodes, httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				tc.handle(i, w, r)
+			},
+		)))
+	}
+
+	return tc
+}
+
+func (tc *testCluster) close() {
+	for _, node := range tc.nodes {
+		node.Close()
+	}
+}
+
+func (tc *testCluster) setDown(i int, down bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.down[i] = down
+}
+
+func (tc *testCluster) setNotLeader(i int, notLeader bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.notLeader[i] = notLeader
+}
+
+func (tc *testCluster) setDropConn(i int, dropConn bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.dropConn[i] = dropConn
+}
+
+func (tc *testCluster) nodeHits(i int) []string {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return append([]string{}, tc.hits[tc.nodes[i].URL]...)
+}
+
+func (tc *testCluster) reset() {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.hits = map[string][]string{}
+}
+
+func (tc *testCluster) handle(i int, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("content-type", "application/json")
+
+	if r.URL.Query().Get("action") == "CLUSTERSTATUS" {
+		atomic.AddInt32(&tc.clusterStatusCalls, 1)
+		_, _ = fmt.Fprintf(w, `{
+			"responseHeader": {"status": 0},
+			"cluster": {
+				"collections": {
+					"products": {
+						"router": {"name": "compositeId"},
+						"shards": {
+							"shard1": {
+								"range": "80000000-ffffffff",
+								"state": "active",
+								"replicas": {
+									"core_node1": {"core": "products_shard1_replica_n1", "base_url": "%[1]s/solr", "node_name": "node1:8983_solr", "state": "active", "type": "NRT", "leader": "true"},
+									"core_node2": {"core": "products_shard1_replica_n2", "base_url": "%[2]s/solr", "node_name": "node2:8983_solr", "state": "active", "type": "NRT"}
+								}
+							},
+							"shard2": {
+								"range": "0-7fffffff",
+								"state": "active",
+								"replicas": {
+									"core_node3": {"core": "products_shard2_replica_n3", "base_url": "%[1]s/solr", "node_name": "node1:8983_solr", "state": "active", "type": "NRT", "leader": "%[3]t"},
+									"core_node4": {"core": "products_shard2_replica_n4", "base_url": "%[2]s/solr", "node_name": "node2:8983_solr", "state": "%[4]s", "type": "NRT", "leader": "%[5]t"}
+								}
+							}
+						}
+					}
+				},
+				"aliases": {"items": "products"},
+				"live_nodes": ["node1:8983_solr", "node2:8983_solr"]
+			}
+		}`, tc.nodes[0].URL, tc.nodes[1].URL, !tc.splitLeaders, shard2ReplicaState(tc.splitLeaders), tc.splitLeaders)
+		return
+	}
+
+	tc.mu.Lock()
+	tc.hits[tc.nodes[i].URL] = append(tc.hits[tc.nodes[i].URL], r.URL.Path)
+	down := tc.down[i]
+	notLeader := tc.notLeader[i] && strings.Contains(r.URL.Path, "_replica_")
+	dropConn := tc.dropConn[i] && strings.Contains(r.URL.Path, "_replica_")
+	tc.mu.Unlock()
+
+	if dropConn {
+		_, _ = io.Copy(io.Discard, r.Body)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		return
+	}
+
+	if down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if notLeader {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"msg":"ClusterState says we are the leader, but locally we don't think so","code":503}}`))
+		return
+	}
+
+	_, _ = w.Write([]byte(`{"responseHeader":{"status":0,"QTime":1}}`))
+}
+
+// shard2ReplicaState returns the state of the second replica of shard2
+func shard2ReplicaState(leader bool) string {
+	if leader {
+		return "active"
+	}
+	return "recovering"
+}
+
+func TestCloudJSONClient(t *testing.T) {
+	ctx := context.Background()
+
+	tc := newTestCluster()
+	defer tc.close()
+
+	client := solr.NewCloudJSONClient(tc.nodes[0].URL).
+		WithRequestSender(solr.NewDefaultRequestSender()).
+		WithRefreshInterval(time.Hour)
+	defer client.Close()
+
+	t.Run("refresh", func(t *testing.T) {
+		err := client.Refresh(ctx)
+		require.NoError(t, err)
+
+		state := client.ClusterState()
+		require.NotNil(t, state)
+		assert.Len(t, state.LiveNodes, 2)
+		assert.Equal(t, "compositeId", state.Collections["products"].Router.Name)
+	})
+
+	t.Run("queries are sent to active replicas", func(t *testing.T) {
+		tc.reset()
+		for i := 0; i < 4; i++ {
+			_, err := client.Query(ctx, "products", solr.NewQuery("*:*"))
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"/solr/products/query", "/solr/products/query"}, tc.nodeHits(0))
+		assert.Equal(t, []string{"/solr/products/query", "/solr/products/query"}, tc.nodeHits(1))
+	})
+
+	t.Run("updates are sent to leaders", func(t *testing.T) {
+		tc.reset()
+		for i := 0; i < 4; i++ {
+			_, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(`[]`))
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, tc.nodeHits(0), 4)
+		assert.Empty(t, tc.nodeHits(1))
+	})
+
+	t.Run("documents are routed to shard leaders", func(t *testing.T) {
+		tc.reset()
+
+		// find ids for each shard
+		var shard1ID, shard2ID string
+		for i := 0; shard1ID == "" || shard2ID == ""; i++ {
+			id := fmt.Sprintf("doc%d", i)
+			if solr.CompositeIDHash(id) < 0 {
+				shard1ID = id
+			} else {
+				shard2ID = id
+			}
+		}
+
+		body := fmt.Sprintf(`[{"id":%q},{"id":%q},{"id":%q}]`, shard1ID, shard2ID, shard1ID)
+		resp, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(body))
+		require.NoError(t, err)
+		// the QTime of the batches is summed
+		assert.Equal(t, 2, resp.Header.QTime)
+
+		assert.Equal(t, []string{
+			"/solr/products_shard1_replica_n1/update",
+			"/solr/products_shard2_replica_n3/update",
+		}, tc.nodeHits(0))
+		assert.Empty(t, tc.nodeHits(1))
+
+		// other updates are sent to any leader
+		tc.reset()
+		_, err = client.Update(ctx, "products", solr.JSON, strings.NewReader(`{"delete":{"query":"*:*"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/solr/products/update"}, tc.nodeHits(0))
+	})
+
+	t.Run("stale leaders", func(t *testing.T) {
+		body := `[{"id":"doc1"},{"id":"doc2"}]`
+
+		t.Run("not leader", func(t *testing.T) {
+			tc.reset()
+			tc.setNotLeader(0, true)
+			defer tc.setNotLeader(0, false)
+
+			calls := atomic.LoadInt32(&tc.clusterStatusCalls)
+			_, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(body))
+			require.NoError(t, err)
+
+			// the rejected batch is forwarded by the cluster
+			hits := tc.nodeHits(0)
+			require.Len(t, hits, 2)
+			assert.Contains(t, hits[0], "_replica_")
+			assert.Equal(t, "/solr/products/update", hits[1])
+
+			assert.Eventually(t, func() bool {
+				return atomic.LoadInt32(&tc.clusterStatusCalls) > calls
+			}, time.Second, 10*time.Millisecond)
+		})
+
+		t.Run("server error", func(t *testing.T) {
+			tc.reset()
+			tc.setDown(0, true)
+			defer tc.setDown(0, false)
+
+			calls := atomic.LoadInt32(&tc.clusterStatusCalls)
+			_, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(body))
+			require.Error(t, err)
+			assert.True(t, solr.IsUnavailable(err))
+			assert.Contains(t, err.Error(), "(0 of ")
+
+			// the update is not resent
+			assert.Len(t, tc.nodeHits(0), 1)
+
+			assert.Eventually(t, func() bool {
+				return atomic.LoadInt32(&tc.clusterStatusCalls) > calls
+			}, time.Second, 10*time.Millisecond)
+		})
+
+		t.Run("connection dropped after the update", func(t *testing.T) {
+			tc.reset()
+			tc.setDropConn(0, true)
+			defer tc.setDropConn(0, false)
+
+			_, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(body))
+			require.Error(t, err)
+
+			// the batch might have been applied so it's not resent
+			assert.Len(t, tc.nodeHits(0), 1)
+			assert.Empty(t, tc.nodeHits(1))
+		})
+	})
+
+	t.Run("aliases", func(t *testing.T) {
+		tc.reset()
+		_, err := client.Update(ctx, "items", solr.JSON, strings.NewReader(`[]`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/solr/items/update"}, tc.nodeHits(0))
+	})
+
+	t.Run("admin requests", func(t *testing.T) {
+		tc.reset()
+		err := client.CreateCollection(ctx, solr.NewCollectionParams().Name("products"))
+		require.NoError(t, err)
+
+		hits := append(tc.nodeHits(0), tc.nodeHits(1)...)
+		assert.Equal(t, []string{"/solr/admin/collections"}, hits)
+	})
+
+	t.Run("fail over and refresh", func(t *testing.T) {
+		tc.reset()
+		tc.setDown(1, true)
+		defer tc.setDown(1, false)
+
+		calls := atomic.LoadInt32(&tc.clusterStatusCalls)
+		for i := 0; i < 2; i++ {
+			_, err := client.Query(ctx, "products", solr.NewQuery("*:*"))
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, tc.nodeHits(0), 2)
+		assert.Len(t, tc.nodeHits(1), 1)
+
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&tc.clusterStatusCalls) > calls
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("updates are not resent after connection errors", func(t *testing.T) {
+		var hits int32
+		broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+		}))
+		defer broken.Close()
+
+		tc := newTestCluster()
+		defer tc.close()
+		tc.splitLeaders = true
+
+		// send the requests to the broken node instead of the leaders
+		rs := &rewriteRequestSender{urls: map[string]string{
+			tc.nodes[0].URL: broken.URL,
+			tc.nodes[1].URL: broken.URL,
+		}}
+		client := solr.NewCloudJSONClient(tc.nodes[0].URL).WithRequestSender(rs)
+		defer client.Close()
+		require.NoError(t, client.Refresh(ctx))
+		rs.enable()
+
+		_, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(`{"delete":{"query":"*:*"}}`))
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		tc.reset()
+		_, err := client.Query(ctx, "unknown", solr.NewQuery("*:*"))
+		require.NoError(t, err)
+
+		hits := append(tc.nodeHits(0), tc.nodeHits(1)...)
+		assert.Equal(t, []string{"/solr/unknown/query"}, hits)
+	})
+
+	t.Run("indexing only client refreshes", func(t *testing.T) {
+		client := solr.NewCloudJSONClient(tc.nodes[0].URL).
+			WithRefreshInterval(10 * time.Millisecond)
+		defer client.Close()
+
+		_, err := client.Update(ctx, "products", solr.JSON, strings.NewReader(`[{"id":"doc1"}]`))
+		require.NoError(t, err)
+
+		calls := atomic.LoadInt32(&tc.clusterStatusCalls)
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&tc.clusterStatusCalls) > calls+1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("refresh error", func(t *testing.T) {
+		client := solr.NewCloudJSONClient("http://127.0.0.1:0")
+		defer client.Close()
+
+		_, err := client.Query(ctx, "products", solr.NewQuery("*:*"))
+		assert.Error(t, err)
+	})
+}
+
+// rewriteRequestSender sends the requests for the nodes to other nodes once enabled
+type rewriteRequestSender struct {
+	urls    map[string]string
+	enabled atomic.Bool
+}
+
+func (rs *rewriteRequestSender) enable() {
+	rs.enabled.Store(true)
+}
+
+func (rs *rewriteRequestSender) SendRequest(ctx context.Context, httpMethod,
+	urlStr, contentType string, body io.Reader) (*http.Response, error) {
+	if rs.enabled.Load() {
+		for from, to := range rs.urls {
+			urlStr = strings.Replace(urlStr, from, to, 1)
+		}
+	}
+
+	return solr.NewDefaultRequestSender().SendRequest(ctx, httpMethod, urlStr, contentType, body)
+}
@@ -0,0 +1,97 @@
+package solr
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CommitOptions is the commit param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#commit-and-optimize-during-updates
+type CommitOptions struct {
+	softCommit,
+	waitSearcher,
+	openSearcher,
+	expungeDeletes *bool
+	commitWithin time.Duration
+}
+
+// NewCommitOptions returns a new CommitOptions
+func NewCommitOptions() *CommitOptions {
+	return &CommitOptions{}
+}
+
+// SoftCommit set to true to perform a soft commit which makes the changes
+// visible without flushing them to stable storage. The default is false.
+func (o *CommitOptions) SoftCommit(softCommit bool) *CommitOptions {
+	o.softCommit = &softCommit
+	return o
+}
+
+// WaitSearcher set to false to return before a new searcher is opened
+// and registered as the main query searcher. The default is true.
+func (o *CommitOptions) WaitSearcher(waitSearcher bool) *CommitOptions {
+	o.waitSearcher = &waitSearcher
+	return o
+}
+
+// OpenSearcher set to false to commit the changes to stable storage
+// without opening a new searcher. The default is true.
+func (o *CommitOptions) OpenSearcher(openSearcher bool) *CommitOptions {
+	o.openSearcher = &openSearcher
+	return o
+}
+
+// ExpungeDeletes set to true to merge segments that have deleted documents.
+// The default is false.
+func (o *CommitOptions) ExpungeDeletes(expungeDeletes bool) *CommitOptions {
+	o.expungeDeletes = &expungeDeletes
+	return o
+}
+
+// CommitWithin adds the update with the guarantee that it will be committed within
+// the given duration instead of committing immediately. Commit ignores this option.
+//
+// The commit flags i.e. SoftCommit, WaitSearcher, OpenSearcher and ExpungeDeletes only
+// apply to an explicit commit so they are not sent with commitWithin. Whether the pending
+// commit is soft or hard is set by the commitWithin config of the update handler.
+func (o *CommitOptions) CommitWithin(commitWithin time.Duration) *CommitOptions {
+	o.commitWithin = commitWithin
+	return o
+}
+
+// BuildParams builds the parameters. If commitWithin is set, only commitWithin is built
+// and the commit flags are left out.
+func (o *CommitOptions) BuildParams() string {
+	vals := &url.Values{}
+
+	if o.commitWithin > 0 {
+		vals.Add("commitWithin", strconv.FormatInt(o.commitWithin.Milliseconds(), 10))
+		return vals.Encode()
+	}
+
+	vals.Add("commit", "true")
+	o.addFlags(vals)
+
+	return vals.Encode()
+}
+
+// addFlags adds the commit flags to the values
+func (o *CommitOptions) addFlags(vals *url.Values) {
+	flags := []struct {
+		name  string
+		value *bool
+	}{
+		{"softCommit", o.softCommit},
+		{"waitSearcher", o.waitSearcher},
+		{"openSearcher", o.openSearcher},
+		{"expungeDeletes", o.expungeDeletes},
+	}
+
+	for _, flag := range flags {
+		if flag.value != nil {
+			vals.Add(flag.name, strconv.FormatBool(*flag.value))
+		}
+	}
+}
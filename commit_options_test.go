@@ -0,0 +1,35 @@
+package solr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildCommitOptions(t *testing.T) {
+	got := solr.NewCommitOptions().
+		SoftCommit(true).
+		WaitSearcher(false).
+		OpenSearcher(true).
+		ExpungeDeletes(true).
+		BuildParams()
+
+	expect := "commit=true&expungeDeletes=true&openSearcher=true&softCommit=true&waitSearcher=false"
+	assert.Equal(t, expect, got)
+
+	got = solr.NewCommitOptions().BuildParams()
+	assert.Equal(t, "commit=true", got)
+
+	// the commit flags are left out with commitWithin
+	got = solr.NewCommitOptions().
+		SoftCommit(true).
+		WaitSearcher(false).
+		OpenSearcher(false).
+		ExpungeDeletes(true).
+		CommitWithin(5 * time.Second).
+		BuildParams()
+	assert.Equal(t, "commitWithin=5000", got)
+}
@@ -6,6 +6,59 @@ type CommonProperty struct {
 	Value interface{}
 }
 
+// ParamSet is a named set of request params that can be referenced via the useParams param
+type ParamSet struct {
+	Name string
+	// Params is the params that are used when not specified in the request
+	Params M
+	// Invariants is the params that can't be overridden by the request
+	Invariants M
+	// Appends is the params that are appended to the params of the request
+	Appends M
+	// Version is the version of the paramset, only available from GetParams
+	Version int
+}
+
+// BuildParamSet builds the paramset
+func (ps ParamSet) BuildParamSet() M {
+	m := M{}
+	for k, v := range ps.Params {
+		m[k] = v
+	}
+
+	if ps.Invariants != nil {
+		m["_invariants_"] = ps.Invariants
+	}
+
+	if ps.Appends != nil {
+		m["_appends_"] = ps.Appends
+	}
+
+	return m
+}
+
+// newParamSet returns the paramset from the params response
+func newParamSet(name string, m M) ParamSet {
+	ps := ParamSet{Name: name, Params: M{}}
+	for k, v := range m {
+		switch k {
+		case "_invariants_":
+			ps.Invariants, _ = v.(map[string]interface{})
+		case "_appends_":
+			ps.Appends, _ = v.(map[string]interface{})
+		case "":
+			// the empty key is the paramset metadata i.e. {"v":0}
+			meta, _ := v.(map[string]interface{})
+			version, _ := toInt64(meta["v"])
+			ps.Version = int(version)
+		default:
+			ps.Params[k] = v
+		}
+	}
+
+	return ps
+}
+
 // ComponentType is a component type
 type ComponentType int
 
@@ -15,6 +68,15 @@ const (
 	SearchComponent
 	InitParams
 	QueryResponseWriter
+	// QueryParserPlugin is the queryparser component, named to
+	// avoid conflicting with the QueryParser interface
+	QueryParserPlugin
+	ValueSourceParser
+	Transformer
+	UpdateProcessor
+	QueryConverter
+	Listener
+	RuntimeLib
 )
 
 func (ct ComponentType) String() string {
@@ -23,6 +85,13 @@ func (ct ComponentType) String() string {
 		"searchcomponent",
 		"initparams",
 		"queryresponsewriter",
+		"queryparser",
+		"valuesourceparser",
+		"transformer",
+		"updateprocessor",
+		"queryconverter",
+		"listener",
+		"runtimelib",
 	}[ct]
 }
 
@@ -36,6 +105,11 @@ type Component struct {
 	class string
 	// m is the component configurations
 	m M
+	// defaults, appends and invariants are the request handler params
+	defaults, appends, invariants M
+	// components, firstComponents and lastComponents are
+	// the search components of a request handler
+	components, firstComponents, lastComponents []string
 }
 
 // NewComponent returns a new Component
@@ -61,13 +135,85 @@ func (c *Component) Config(m M) *Component {
 	return c
 }
 
-// BuildComponent builds the component config
-func (c *Component) BuildComponent() M {
-	m := M{"name": c.name, "class": c.class}
+// Defaults sets the request handler params that are used when not specified in the request
+func (c *Component) Defaults(defaults M) *Component {
+	c.defaults = defaults
+	return c
+}
 
+// Appends sets the request handler params that are appended to the params of the request
+func (c *Component) Appends(appends M) *Component {
+	c.appends = appends
+	return c
+}
+
+// Invariants sets the request handler params that can't be overridden by the request
+func (c *Component) Invariants(invariants M) *Component {
+	c.invariants = invariants
+	return c
+}
+
+// Components sets the search components of a request handler, replacing the default components
+func (c *Component) Components(components ...string) *Component {
+	c.components = components
+	return c
+}
+
+// FirstComponents sets the search components that run before the default components
+func (c *Component) FirstComponents(components ...string) *Component {
+	c.firstComponents = components
+	return c
+}
+
+// LastComponents sets the search components that run after the default components
+func (c *Component) LastComponents(components ...string) *Component {
+	c.lastComponents = components
+	return c
+}
+
+// BuildComponent builds the component config. The name and class are omitted if empty
+// e.g. runtimelib doesn't have a class. The typed blocks override the same keys in Config.
+func (c *Component) BuildComponent() M {
+	m := M{}
 	for k, v := range c.m {
 		m[k] = v
 	}
 
+	if c.name != "" {
+		m["name"] = c.name
+	}
+
+	if c.class != "" {
+		m["class"] = c.class
+	}
+
+	params := []struct {
+		name  string
+		value M
+	}{
+		{"defaults", c.defaults},
+		{"appends", c.appends},
+		{"invariants", c.invariants},
+	}
+	for _, param := range params {
+		if param.value != nil {
+			m[param.name] = param.value
+		}
+	}
+
+	lists := []struct {
+		name  string
+		value []string
+	}{
+		{"components", c.components},
+		{"first-components", c.firstComponents},
+		{"last-components", c.lastComponents},
+	}
+	for _, list := range lists {
+		if list.value != nil {
+			m[list.name] = list.value
+		}
+	}
+
 	return m
 }
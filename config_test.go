@@ -30,6 +30,51 @@ func TestBuildComponent(t *testing.T) {
 	assert.Equal(t, expect, got)
 }
 
+func TestBuildRequestHandler(t *testing.T) {
+	got := solr.NewComponent(solr.RequestHandler).
+		Name("/browse").Class("solr.SearchHandler").
+		Config(solr.M{"startup": "lazy", "defaults": solr.M{"rows": 5}}).
+		Defaults(solr.M{"rows": 10, "df": "name"}).
+		Appends(solr.M{"fq": "inStock:true"}).
+		Invariants(solr.M{"facet": false}).
+		FirstComponents("elevator").
+		LastComponents("spellcheck", "suggest").
+		BuildComponent()
+
+	expect := solr.M{
+		"name":             "/browse",
+		"class":            "solr.SearchHandler",
+		"startup":          "lazy",
+		"defaults":         solr.M{"rows": 10, "df": "name"},
+		"appends":          solr.M{"fq": "inStock:true"},
+		"invariants":       solr.M{"facet": false},
+		"first-components": []string{"elevator"},
+		"last-components":  []string{"spellcheck", "suggest"},
+	}
+	assert.Equal(t, expect, got)
+
+	got = solr.NewComponent(solr.RequestHandler).
+		Name("/mlt").Class("solr.SearchHandler").
+		Components("mlt", "debug").
+		BuildComponent()
+
+	expect = solr.M{
+		"name":       "/mlt",
+		"class":      "solr.SearchHandler",
+		"components": []string{"mlt", "debug"},
+	}
+	assert.Equal(t, expect, got)
+}
+
+func TestBuildComponentOmitsEmpty(t *testing.T) {
+	got := solr.NewComponent(solr.RuntimeLib).
+		Name("jarblob").
+		Config(solr.M{"version": 2}).
+		BuildComponent()
+
+	assert.Equal(t, solr.M{"name": "jarblob", "version": 2}, got)
+}
+
 func TestComponentTypeStringer(t *testing.T) {
 	var tests = []struct {
 		componentType solr.ComponentType
@@ -51,6 +96,34 @@ func TestComponentTypeStringer(t *testing.T) {
 			solr.QueryResponseWriter,
 			"queryresponsewriter",
 		},
+		{
+			solr.QueryParserPlugin,
+			"queryparser",
+		},
+		{
+			solr.ValueSourceParser,
+			"valuesourceparser",
+		},
+		{
+			solr.Transformer,
+			"transformer",
+		},
+		{
+			solr.UpdateProcessor,
+			"updateprocessor",
+		},
+		{
+			solr.QueryConverter,
+			"queryconverter",
+		},
+		{
+			solr.Listener,
+			"listener",
+		},
+		{
+			solr.RuntimeLib,
+			"runtimelib",
+		},
 	}
 
 	for _, test := range tests {
@@ -58,3 +131,20 @@ func TestComponentTypeStringer(t *testing.T) {
 		assert.Equal(t, test.expected, got)
 	}
 }
+
+func TestBuildParamSet(t *testing.T) {
+	got := solr.ParamSet{
+		Name:       "myQueries",
+		Params:     solr.M{"defType": "edismax", "rows": "5"},
+		Invariants: solr.M{"wt": "json"},
+		Appends:    solr.M{"fq": "inStock:true"},
+	}.BuildParamSet()
+
+	expect := solr.M{
+		"defType":      "edismax",
+		"rows":         "5",
+		"_invariants_": solr.M{"wt": "json"},
+		"_appends_":    solr.M{"fq": "inStock:true"},
+	}
+	assert.Equal(t, expect, got)
+}
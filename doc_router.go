@@ -0,0 +1,238 @@
+package solr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// List of document routers
+const (
+	CompositeIDRouter = "compositeId"
+	ImplicitRouter    = "implicit"
+)
+
+// Route returns the name of the active shard that owns the given route value
+// i.e. the uniqueKey or the value of the router.field of a document.
+//
+// Refer to https://solr.apache.org/guide/8_8/shards-and-indexing-data-in-solrcloud.html#document-routing
+func (cs *CollectionState) Route(routeValue string) (string, error) {
+	router := CompositeIDRouter
+	if cs.Router != nil && cs.Router.Name != "" {
+		router = cs.Router.Name
+	}
+
+	switch router {
+	case CompositeIDRouter:
+		hash := CompositeIDHash(routeValue)
+		for name, shard := range cs.Shards {
+			if shard.State != "active" {
+				continue
+			}
+
+			minHash, maxHash, err := ParseHashRange(shard.Range)
+			if err != nil {
+				return "", wrapErr(err, "parse hash range")
+			}
+
+			if minHash <= hash && hash <= maxHash {
+				return name, nil
+			}
+		}
+
+		return "", fmt.Errorf("no active shard for %q", routeValue)
+	case ImplicitRouter:
+		// the route value is the shard name
+		if shard, ok := cs.Shards[routeValue]; ok && shard.State == "active" {
+			return routeValue, nil
+		}
+
+		return "", fmt.Errorf("no active shard named %q", routeValue)
+	}
+
+	return "", fmt.Errorf("unsupported router %q", router)
+}
+
+// ParseHashRange parses the hash range of a shard e.g. "80000000-ffffffff"
+func ParseHashRange(hashRange string) (minHash, maxHash int32, err error) {
+	parts := strings.SplitN(hashRange, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid hash range %q", hashRange)
+	}
+
+	minVal, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return 0, 0, wrapErr(err, "parse min hash")
+	}
+
+	maxVal, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return 0, 0, wrapErr(err, "parse max hash")
+	}
+
+	return int32(uint32(minVal)), int32(uint32(maxVal)), nil
+}
+
+// CompositeIDHash returns the hash of the document id as computed by Solr's
+// compositeId router. Ids with a shard key prefix e.g. "shardKey!docId" use the
+// upper 16 bits of the shard key hash and the lower 16 bits of the doc id hash.
+// The number of bits can be customized using a "/bits" suffix e.g. "shardKey/4!docId".
+// Up to two levels of shard keys are supported e.g. "tenant!user!docId".
+func CompositeIDHash(id string) int32 {
+	if !strings.Contains(id, "!") {
+		return int32(murmurhash3x86_32([]byte(id), 0))
+	}
+
+	parts := splitCompositeID(id)
+
+	pieces := len(parts)
+	if strings.HasSuffix(id, "!") && pieces < 3 {
+		pieces++
+	}
+
+	numBits := [2]int{16, 0}
+	if pieces == 3 {
+		numBits = [2]int{8, 8}
+	}
+
+	hashes := make([]uint32, pieces)
+	for i := 0; i < pieces; i++ {
+		part := ""
+		if i < len(parts) {
+			part = parts[i]
+		}
+
+		// only the shard keys can have a bits suffix
+		if i < pieces-1 {
+			if idx := strings.IndexByte(part, '/'); idx > 0 {
+				if n, ok := parseNumBits(part[idx+1:]); ok {
+					numBits[i] = n
+				}
+				part = part[:idx]
+			}
+		}
+
+		hashes[i] = murmurhash3x86_32([]byte(part), 0)
+	}
+
+	masks := compositeIDMasks(pieces, numBits)
+	hash := hashes[0] & masks[0]
+	for i := 1; i < pieces; i++ {
+		hash |= hashes[i] & masks[i]
+	}
+
+	return int32(hash)
+}
+
+// splitCompositeID splits the id on the first two '!' separators
+// the same way as Solr's CompositeIdRouter
+func splitCompositeID(id string) []string {
+	first := strings.IndexByte(id, '!')
+	if first == -1 {
+		return []string{id}
+	}
+
+	parts := []string{id[:first]}
+	last := len(id) - 1
+	if first == last {
+		return parts
+	}
+
+	second := strings.IndexByte(id[first+1:], '!')
+	switch {
+	case second == -1:
+		parts = append(parts, id[first+1:])
+	case first+1+second == last:
+		// exactly two separators and they're the last two chars
+		if second > 0 {
+			parts = append(parts, id[first+1:last])
+		}
+	default:
+		second += first + 1
+		parts = append(parts, id[first+1:second], id[second+1:])
+	}
+
+	return parts
+}
+
+// parseNumBits parses the bits suffix of a shard key, capped at 16 bits
+func parseNumBits(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	if n > 16 {
+		n = 16
+	}
+
+	return n, true
+}
+
+// compositeIDMasks returns the bit masks for each piece of the id
+func compositeIDMasks(pieces int, numBits [2]int) []uint32 {
+	mask := func(n int) uint32 {
+		if n == 0 {
+			return 0
+		}
+		return ^uint32(0) << (32 - n)
+	}
+
+	if pieces == 3 {
+		first := mask(numBits[0])
+		second := mask(numBits[0]+numBits[1]) ^ first
+		return []uint32{first, second, ^(first | second)}
+	}
+
+	first := mask(numBits[0])
+	return []uint32{first, ^first}
+}
+
+// murmurhash3x86_32 is the 32-bit x86 variant of MurmurHash3
+func murmurhash3x86_32(data []byte, seed uint32) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+
+	h := seed
+	nblocks := len(data) / 4
+	for i := 0; i < nblocks; i++ {
+		k := binary.LittleEndian.Uint32(data[i*4:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	tail := data[nblocks*4:]
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+
+	return h
+}
@@ -0,0 +1,139 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_murmurhash3x86_32(t *testing.T) {
+	var tests = []struct {
+		data   string
+		seed   uint32
+		expect uint32
+	}{
+		{"", 0, 0},
+		{"", 1, 0x514e28b7},
+		{"test", 0, 0xba6bd213},
+		{"Hello, world!", 0x9747b28c, 0x24884cba},
+		{"The quick brown fox jumps over the lazy dog", 0x9747b28c, 0x2fa826cd},
+	}
+
+	for _, test := range tests {
+		got := murmurhash3x86_32([]byte(test.data), test.seed)
+		assert.Equal(t, test.expect, got, test.data)
+	}
+}
+
+func TestCompositeIDHash(t *testing.T) {
+	hash := func(s string) uint32 {
+		return murmurhash3x86_32([]byte(s), 0)
+	}
+
+	t.Run("plain id", func(t *testing.T) {
+		assert.Equal(t, int32(hash("doc1")), CompositeIDHash("doc1"))
+	})
+
+	t.Run("shard key", func(t *testing.T) {
+		expect := hash("IBM")&0xffff0000 | hash("doc1")&0x0000ffff
+		assert.Equal(t, int32(expect), CompositeIDHash("IBM!doc1"))
+	})
+
+	t.Run("shard key with bits", func(t *testing.T) {
+		expect := hash("IBM")&0xf0000000 | hash("doc1")&0x0fffffff
+		assert.Equal(t, int32(expect), CompositeIDHash("IBM/4!doc1"))
+
+		// bits are capped at 16
+		expect = hash("IBM")&0xffff0000 | hash("doc1")&0x0000ffff
+		assert.Equal(t, int32(expect), CompositeIDHash("IBM/32!doc1"))
+	})
+
+	t.Run("two shard keys", func(t *testing.T) {
+		expect := hash("tenant")&0xff000000 | hash("user")&0x00ff0000 | hash("doc1")&0x0000ffff
+		assert.Equal(t, int32(expect), CompositeIDHash("tenant!user!doc1"))
+
+		expect = hash("tenant")&0xf0000000 | hash("user")&0x0ff00000 | hash("doc1")&0x000fffff
+		assert.Equal(t, int32(expect), CompositeIDHash("tenant/4!user!doc1"))
+	})
+
+	t.Run("trailing separator", func(t *testing.T) {
+		expect := hash("IBM")&0xffff0000 | hash("")&0x0000ffff
+		assert.Equal(t, int32(expect), CompositeIDHash("IBM!"))
+
+		expect = hash("tenant")&0xff000000 | hash("user")&0x00ff0000 | hash("")&0x0000ffff
+		assert.Equal(t, int32(expect), CompositeIDHash("tenant!user!"))
+	})
+}
+
+func TestParseHashRange(t *testing.T) {
+	minHash, maxHash, err := ParseHashRange("80000000-ffffffff")
+	require.NoError(t, err)
+	assert.Equal(t, int32(-2147483648), minHash)
+	assert.Equal(t, int32(-1), maxHash)
+
+	minHash, maxHash, err = ParseHashRange("0-7fffffff")
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), minHash)
+	assert.Equal(t, int32(2147483647), maxHash)
+
+	_, _, err = ParseHashRange("0")
+	assert.Error(t, err)
+
+	_, _, err = ParseHashRange("x-7fffffff")
+	assert.Error(t, err)
+
+	_, _, err = ParseHashRange("0-x")
+	assert.Error(t, err)
+}
+
+func TestCollectionStateRoute(t *testing.T) {
+	t.Run("compositeId", func(t *testing.T) {
+		cs := &CollectionState{
+			Router: &DocRouterSpec{Name: CompositeIDRouter},
+			Shards: map[string]*ShardState{
+				"shard1": {Range: "80000000-ffffffff", State: "active"},
+				"shard2": {Range: "0-7fffffff", State: "active"},
+			},
+		}
+
+		for _, id := range []string{"doc1", "doc2", "IBM!doc1", "tenant!user!doc1"} {
+			expect := "shard2"
+			if CompositeIDHash(id) < 0 {
+				expect = "shard1"
+			}
+
+			got, err := cs.Route(id)
+			require.NoError(t, err)
+			assert.Equal(t, expect, got, id)
+		}
+
+		cs.Shards["shard1"].State = "inactive"
+		cs.Shards["shard2"].State = "inactive"
+		_, err := cs.Route("doc1")
+		assert.Error(t, err)
+	})
+
+	t.Run("implicit", func(t *testing.T) {
+		cs := &CollectionState{
+			Router: &DocRouterSpec{Name: ImplicitRouter, Field: "region"},
+			Shards: map[string]*ShardState{
+				"emea": {State: "active"},
+				"apac": {State: "active"},
+			},
+		}
+
+		got, err := cs.Route("emea")
+		require.NoError(t, err)
+		assert.Equal(t, "emea", got)
+
+		_, err = cs.Route("amer")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported router", func(t *testing.T) {
+		cs := &CollectionState{Router: &DocRouterSpec{Name: "custom"}}
+		_, err := cs.Route("doc1")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,539 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structField is a struct field mapped to a Solr field
+type structField struct {
+	// name is the Solr field name
+	name string
+	// opts is the tag options after the field name
+	opts []string
+	// index is the index sequence of the struct field
+	index []int
+}
+
+// fieldsCache caches the struct fields by type
+var fieldsCache sync.Map
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// cachedFields returns the struct fields mapped to Solr fields. The field name is
+// taken from the `solr` tag, then from the `json` tag, then from the Go field name.
+// Fields tagged with `solr:"-"` are skipped and untagged embedded structs are flattened.
+func cachedFields(t reflect.Type) []structField {
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+
+	fields := typeFields(t, nil)
+	fieldsCache.Store(t, fields)
+	return fields
+}
+
+func typeFields(t reflect.Type, index []int) []structField {
+	fields := []structField{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag, hasTag := sf.Tag.Lookup("solr")
+		if !hasTag {
+			tag, hasTag = sf.Tag.Lookup("json")
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+
+		// flatten untagged embedded structs
+		if sf.Anonymous && !hasTag {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, typeFields(ft, fieldIndex)...)
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields = append(fields, structField{
+			name:  name,
+			opts:  parts[1:],
+			index: fieldIndex,
+		})
+	}
+
+	return fields
+}
+
+// DecodeDocument decodes the document into v which must be a pointer to a struct.
+// Struct fields are mapped using the `solr` tag e.g. `solr:"name"`.
+//
+// Multi-valued fields are decoded into slices, a single value is wrapped when decoding
+// into a slice and the first value is taken when decoding a multi-valued field into
+// a non-slice. Numbers are converted to the numeric kind of the struct field, Solr date
+// strings are parsed into time.Time and nested child documents (e.g. _childDocuments_)
+// are decoded into slices of structs.
+func DecodeDocument(doc M, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("decode document: expecting a non-nil pointer")
+	}
+
+	return decodeValue(map[string]interface{}(doc), rv.Elem())
+}
+
+// unmarshalDocument decodes the JSON document with the numbers as json.Number
+func unmarshalDocument(b []byte) (M, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc M
+	err := dec.Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// decodeStruct decodes the document into the struct
+func decodeStruct(doc map[string]interface{}, rv reflect.Value) error {
+	for _, sf := range cachedFields(rv.Type()) {
+		val, ok := doc[sf.name]
+		if !ok {
+			continue
+		}
+
+		fv, err := fieldByIndex(rv, sf.index)
+		if err != nil {
+			return err
+		}
+
+		err = decodeValue(val, fv)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", sf.name, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndex returns the nested field, allocating nil embedded struct pointers
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, error) {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", rv.Type().Elem())
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(idx)
+	}
+
+	return rv, nil
+}
+
+// decodeValue decodes the value from the decoded JSON into dst
+func decodeValue(val interface{}, dst reflect.Value) error {
+	if val == nil {
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(val, dst.Elem())
+	case reflect.Interface:
+		v := reflect.ValueOf(val)
+		if !v.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("cannot decode %T into %s", val, dst.Type())
+		}
+		dst.Set(v)
+		return nil
+	case reflect.Slice:
+		vals, ok := asSlice(val)
+		if !ok {
+			// single value into a multi-valued field
+			vals = []interface{}{val}
+		}
+
+		slice := reflect.MakeSlice(dst.Type(), len(vals), len(vals))
+		for i, v := range vals {
+			err := decodeValue(v, slice.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	}
+
+	// multi-valued field into a single value
+	if vals, ok := asSlice(val); ok {
+		if len(vals) == 0 {
+			return nil
+		}
+		val = vals[0]
+	}
+
+	if dst.Type() == timeType {
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into time.Time", val)
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return wrapErr(err, "parse date")
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		doc, ok := asDocument(val)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into %s", val, dst.Type())
+		}
+		return decodeStruct(doc, dst)
+	case reflect.Map:
+		doc, ok := asDocument(val)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot decode %T into %s", val, dst.Type())
+		}
+
+		m := reflect.MakeMapWithSize(dst.Type(), len(doc))
+		for k, v := range doc {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			err := decodeValue(v, elem)
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(m)
+		return nil
+	case reflect.String:
+		switch v := val.(type) {
+		case string:
+			dst.SetString(v)
+		case json.Number:
+			dst.SetString(v.String())
+		default:
+			return fmt.Errorf("cannot decode %T into %s", val, dst.Type())
+		}
+		return nil
+	case reflect.Bool:
+		switch v := val.(type) {
+		case bool:
+			dst.SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return wrapErr(err, "parse bool")
+			}
+			dst.SetBool(b)
+		default:
+			return fmt.Errorf("cannot decode %T into %s", val, dst.Type())
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(val)
+		if err != nil {
+			return err
+		}
+
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("%d overflows %s", n, dst.Type())
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toUint64(val)
+		if err != nil {
+			return err
+		}
+
+		if dst.OverflowUint(n) {
+			return fmt.Errorf("%d overflows %s", n, dst.Type())
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(val)
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+
+	return fmt.Errorf("cannot decode %T into %s", val, dst.Type())
+}
+
+// asSlice returns the values of a multi-valued field
+func asSlice(val interface{}) ([]interface{}, bool) {
+	if vals, ok := val.([]interface{}); ok {
+		return vals, true
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	vals := make([]interface{}, rv.Len())
+	for i := range vals {
+		vals[i] = rv.Index(i).Interface()
+	}
+
+	return vals, true
+}
+
+// asDocument returns the fields of a nested document
+func asDocument(val interface{}) (map[string]interface{}, bool) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return v, true
+	case M:
+		return v, true
+	}
+
+	return nil, false
+}
+
+// EncodeDocument encodes v into a document. v can be a struct or a pointer to a
+// struct mapped using the `solr` tag, an M, a map[string]interface{} or an *AtomicUpdate.
+//
+// Struct fields with the "omitempty" option e.g. `solr:"name,omitempty"` and nil
+// pointers are skipped. time.Time values are encoded as Solr dates in UTC and nested
+// structs are encoded as child documents.
+func EncodeDocument(v interface{}) (M, error) {
+	if au, ok := v.(*AtomicUpdate); ok {
+		return au.BuildDocument(), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("encode document: nil document")
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return encodeStruct(rv), nil
+	case reflect.Map:
+		doc, ok := encodeMap(rv)
+		if ok {
+			return doc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("encode document: cannot encode %T", v)
+}
+
+// encodeStruct encodes the struct into a document
+func encodeStruct(rv reflect.Value) M {
+	doc := M{}
+	for _, sf := range cachedFields(rv.Type()) {
+		fv, ok := fieldByIndexNoAlloc(rv, sf.index)
+		if !ok {
+			continue
+		}
+
+		if hasOption(sf.opts, "omitempty") && fv.IsZero() {
+			continue
+		}
+
+		val, ok := encodeValue(fv)
+		if !ok {
+			continue
+		}
+		doc[sf.name] = val
+	}
+
+	return doc
+}
+
+// encodeMap encodes the map with string keys into a document
+func encodeMap(rv reflect.Value) (M, bool) {
+	if rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	doc := M{}
+	iter := rv.MapRange()
+	for iter.Next() {
+		val, ok := encodeValue(iter.Value())
+		if !ok {
+			continue
+		}
+		doc[iter.Key().String()] = val
+	}
+
+	return doc, true
+}
+
+// fieldByIndexNoAlloc returns the nested field, false if an embedded struct pointer is nil
+func fieldByIndexNoAlloc(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(idx)
+	}
+
+	return rv, true
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// encodeValue encodes the field value, false if the value should be skipped
+func encodeValue(rv reflect.Value) (interface{}, bool) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, false
+		}
+		return encodeValue(rv.Elem())
+	}
+
+	if rv.Type() == timeType {
+		return rv.Interface().(time.Time).UTC().Format(time.RFC3339Nano), true
+	}
+
+	if rv.Type().Implements(jsonMarshalerType) {
+		return rv.Interface(), true
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return encodeStruct(rv), true
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, false
+		}
+
+		doc, ok := encodeMap(rv)
+		if !ok {
+			return rv.Interface(), true
+		}
+		return doc, true
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, false
+		}
+
+		// leave byte slices to the JSON encoder
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface(), true
+		}
+
+		vals := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			val, ok := encodeValue(rv.Index(i))
+			if !ok {
+				continue
+			}
+			vals = append(vals, val)
+		}
+		return vals, true
+	}
+
+	return rv.Interface(), true
+}
+
+// hasOption returns true if the tag options contain the option
+func hasOption(opts []string, opt string) bool {
+	for _, o := range opts {
+		if o == opt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toInt64 converts the decoded JSON number to int64
+func toInt64(val interface{}) (int64, error) {
+	switch v := val.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("cannot decode %v into an integer", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+
+	return 0, fmt.Errorf("cannot decode %T into an integer", val)
+}
+
+// toUint64 converts the decoded JSON number to uint64
+func toUint64(val interface{}) (uint64, error) {
+	switch v := val.(type) {
+	case float64:
+		if v < 0 || v != math.Trunc(v) {
+			return 0, fmt.Errorf("cannot decode %v into an unsigned integer", v)
+		}
+		return uint64(v), nil
+	case json.Number:
+		return strconv.ParseUint(v.String(), 10, 64)
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	}
+
+	return 0, fmt.Errorf("cannot decode %T into an unsigned integer", val)
+}
+
+// toFloat64 converts the decoded JSON number to float64
+func toFloat64(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+
+	return 0, fmt.Errorf("cannot decode %T into a float", val)
+}
@@ -0,0 +1,239 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+type Base struct {
+	ID string `solr:"id"`
+}
+
+type Variant struct {
+	ID    string  `solr:"id"`
+	Color string  `solr:"color_s"`
+	Price float32 `solr:"price_f"`
+}
+
+type Product struct {
+	Base
+	Name       string    `solr:"name"`
+	Tags       []string  `solr:"tags_ss"`
+	Category   string    `solr:"cat"`
+	InStock    bool      `solr:"inStock"`
+	Count      int       `json:"count_i"`
+	Rank       *int64    `solr:"rank_l"`
+	Score      float64   `solr:"score"`
+	Created    time.Time `solr:"created_dt"`
+	Variants   []Variant `solr:"_childDocuments_"`
+	Ignored    string    `solr:"-"`
+	Unmapped   string
+	unexported string
+}
+
+func decodeJSONDoc(t *testing.T, s string) solr.M {
+	var doc solr.M
+	require.NoError(t, json.Unmarshal([]byte(s), &doc))
+	return doc
+}
+
+func TestDecodeDocument(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		doc := decodeJSONDoc(t, `{
+			"id": "p1",
+			"name": ["Solr in Action"],
+			"tags_ss": "search",
+			"cat": ["books", "search"],
+			"inStock": true,
+			"count_i": 42,
+			"rank_l": 7,
+			"score": 1.5,
+			"created_dt": "2021-03-04T05:06:07Z",
+			"_childDocuments_": [
+				{"id": "v1", "color_s": "red", "price_f": 9.99},
+				{"id": "v2", "color_s": "blue", "price_f": 19.99}
+			],
+			"Ignored": "ignored",
+			"Unmapped": "mapped by field name"
+		}`)
+
+		var product Product
+		err := solr.DecodeDocument(doc, &product)
+		require.NoError(t, err)
+
+		rank := int64(7)
+		expect := Product{
+			Base:     Base{ID: "p1"},
+			Name:     "Solr in Action",
+			Tags:     []string{"search"},
+			Category: "books",
+			InStock:  true,
+			Count:    42,
+			Rank:     &rank,
+			Score:    1.5,
+			Created:  time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+			Variants: []Variant{
+				{ID: "v1", Color: "red", Price: 9.99},
+				{ID: "v2", Color: "blue", Price: 19.99},
+			},
+			Unmapped: "mapped by field name",
+		}
+		assert.Equal(t, expect, product)
+	})
+
+	t.Run("map and interface fields", func(t *testing.T) {
+		var v struct {
+			Attrs map[string]int `solr:"attrs"`
+			Any   interface{}    `solr:"any"`
+		}
+
+		doc := solr.M{"attrs": solr.M{"a": 1.0, "b": 2.0}, "any": "value"}
+		err := solr.DecodeDocument(doc, &v)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, v.Attrs)
+		assert.Equal(t, "value", v.Any)
+	})
+
+	t.Run("long values", func(t *testing.T) {
+		var v struct {
+			Version int64   `solr:"_version_"`
+			ID      uint64  `solr:"id"`
+			Price   float64 `solr:"price_d"`
+		}
+
+		// above 2^53, can't be represented exactly as float64
+		doc := solr.M{
+			"_version_": json.Number("1712345678901234567"),
+			"id":        json.Number("18446744073709551615"),
+			"price_d":   json.Number("9.99"),
+		}
+		err := solr.DecodeDocument(doc, &v)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1712345678901234567), v.Version)
+		assert.Equal(t, uint64(18446744073709551615), v.ID)
+		assert.Equal(t, 9.99, v.Price)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var product Product
+		err := solr.DecodeDocument(solr.M{}, product)
+		assert.Error(t, err)
+
+		var tests = []struct {
+			name   string
+			doc    solr.M
+			errMsg string
+		}{
+			{"overflow", solr.M{"v": 300.0}, `field "v"`},
+			{"fraction", solr.M{"v": 1.5}, `field "v"`},
+			{"type mismatch", solr.M{"v": "abc"}, `field "v"`},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var v struct {
+					V int8 `solr:"v"`
+				}
+				err := solr.DecodeDocument(test.doc, &v)
+				require.Error(t, err)
+				assert.True(t, strings.Contains(err.Error(), test.errMsg))
+			})
+		}
+
+		var u struct {
+			V uint `solr:"v"`
+		}
+		err = solr.DecodeDocument(solr.M{"v": -1.0}, &u)
+		assert.Error(t, err)
+
+		var d struct {
+			V time.Time `solr:"v"`
+		}
+		err = solr.DecodeDocument(solr.M{"v": "yesterday"}, &d)
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodeDocument(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		rank := int64(7)
+		product := &Product{
+			Base:     Base{ID: "p1"},
+			Name:     "Solr in Action",
+			Tags:     []string{"search"},
+			InStock:  true,
+			Count:    42,
+			Rank:     &rank,
+			Created:  time.Date(2021, 3, 4, 13, 6, 7, 0, time.FixedZone("UTC+8", 8*60*60)),
+			Variants: []Variant{{ID: "v1", Color: "red", Price: 9.5}},
+			Ignored:  "ignored",
+		}
+
+		got, err := solr.EncodeDocument(product)
+		require.NoError(t, err)
+
+		expect := solr.M{
+			"id":         "p1",
+			"name":       "Solr in Action",
+			"tags_ss":    []interface{}{"search"},
+			"cat":        "",
+			"inStock":    true,
+			"count_i":    42,
+			"rank_l":     int64(7),
+			"score":      0.0,
+			"created_dt": "2021-03-04T05:06:07Z",
+			"_childDocuments_": []interface{}{
+				solr.M{"id": "v1", "color_s": "red", "price_f": float32(9.5)},
+			},
+			"Unmapped": "",
+		}
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("omitempty", func(t *testing.T) {
+		var v struct {
+			ID      string    `solr:"id"`
+			Name    string    `solr:"name,omitempty"`
+			Created time.Time `solr:"created_dt,omitempty"`
+			Tags    []string  `solr:"tags_ss"`
+			Rank    *int      `solr:"rank_i"`
+		}
+		v.ID = "p1"
+
+		got, err := solr.EncodeDocument(v)
+		require.NoError(t, err)
+		assert.Equal(t, solr.M{"id": "p1"}, got)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		got, err := solr.EncodeDocument(map[string]interface{}{"id": "p1", "created_dt": created})
+		require.NoError(t, err)
+		assert.Equal(t, solr.M{"id": "p1", "created_dt": "2021-03-04T05:06:07Z"}, got)
+	})
+
+	t.Run("atomic update", func(t *testing.T) {
+		got, err := solr.EncodeDocument(solr.NewAtomicUpdate("p1").Set("name", "x"))
+		require.NoError(t, err)
+		assert.Equal(t, solr.M{"id": "p1", "name": solr.M{"set": "x"}}, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var product *Product
+		_, err := solr.EncodeDocument(product)
+		assert.Error(t, err)
+
+		_, err = solr.EncodeDocument("p1")
+		assert.Error(t, err)
+
+		_, err = solr.EncodeDocument(map[int]string{1: "p1"})
+		assert.Error(t, err)
+	})
+}
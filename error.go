@@ -0,0 +1,128 @@
+package solr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// List of sentinel errors that can be matched against a
+// SolrError using errors.Is based on the response status code
+var (
+	ErrBadRequest  = errors.New("bad request")
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrUnavailable = errors.New("service unavailable")
+)
+
+// SolrError is the error returned when Solr replies with an error response
+type SolrError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// URL is the request URL
+	URL string
+	// ErrorClass is the exception class from the error metadata
+	ErrorClass string
+	// RootErrorClass is the root exception class from the error metadata
+	RootErrorClass string
+	// Err is the error from the response body
+	Err *ResponseError
+}
+
+var _ error = (*SolrError)(nil)
+
+// newSolrError returns a new SolrError
+func newSolrError(resp *http.Response, respErr *ResponseError) *SolrError {
+	if respErr == nil {
+		respErr = &ResponseError{
+			Code: resp.StatusCode,
+			Msg:  http.StatusText(resp.StatusCode),
+		}
+	}
+
+	solrErr := &SolrError{
+		StatusCode: resp.StatusCode,
+		Err:        respErr,
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		solrErr.URL = resp.Request.URL.String()
+	}
+
+	// metadata is a flat list of key-value pairs
+	for i := 0; i+1 < len(respErr.Metadata); i += 2 {
+		switch respErr.Metadata[i] {
+		case "error-class":
+			solrErr.ErrorClass = respErr.Metadata[i+1]
+		case "root-error-class":
+			solrErr.RootErrorClass = respErr.Metadata[i+1]
+		}
+	}
+
+	return solrErr
+}
+
+// Error implements error
+func (e *SolrError) Error() string {
+	msg := http.StatusText(e.StatusCode)
+	if e.Err != nil && e.Err.Msg != "" {
+		msg = e.Err.Msg
+	}
+
+	if e.Err != nil && len(e.Err.Details) > 0 {
+		details := make([]string, len(e.Err.Details))
+		for i, detail := range e.Err.Details {
+			details[i] = detail.Error()
+		}
+		msg += " (" + strings.Join(details, "; ") + ")"
+	}
+
+	return fmt.Sprintf("solr: status %d: %s", e.StatusCode, msg)
+}
+
+// Unwrap returns the underlying response error
+func (e *SolrError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+
+	return e.Err
+}
+
+// Is matches the sentinel errors by status code
+func (e *SolrError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+
+	return false
+}
+
+// IsBadRequest returns true if the error is a bad request (400) error
+func IsBadRequest(err error) bool {
+	return errors.Is(err, ErrBadRequest)
+}
+
+// IsNotFound returns true if the error is a not found (404) error
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if the error is a conflict (409) error
+// e.g. an optimistic concurrency version conflict
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnavailable returns true if the error is a service unavailable (503) error
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
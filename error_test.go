@@ -0,0 +1,75 @@
+package solr
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolrError(t *testing.T) {
+	reqURL, err := url.Parse("https://solr.example.com/solr/products/query")
+	require.NoError(t, err)
+
+	resp := &http.Response{
+		StatusCode: http.StatusConflict,
+		Request:    &http.Request{URL: reqURL},
+	}
+	solrErr := newSolrError(resp, &ResponseError{
+		Code: http.StatusConflict,
+		Metadata: []string{
+			"error-class", "org.apache.solr.common.SolrException",
+			"root-error-class", "org.apache.solr.common.SolrException",
+		},
+		Msg:   "version conflict for 1 expected=1 actual=2",
+		Trace: "org.apache.solr.common.SolrException: version conflict",
+	})
+
+	assert.Equal(t, http.StatusConflict, solrErr.StatusCode)
+	assert.Equal(t, reqURL.String(), solrErr.URL)
+	assert.Equal(t, "org.apache.solr.common.SolrException", solrErr.ErrorClass)
+	assert.Equal(t, "org.apache.solr.common.SolrException", solrErr.RootErrorClass)
+	assert.Equal(t, "solr: status 409: version conflict for 1 expected=1 actual=2", solrErr.Error())
+
+	wrappedErr := wrapErr(solrErr, "read response")
+	assert.True(t, IsConflict(wrappedErr))
+	assert.False(t, IsNotFound(wrappedErr))
+	assert.False(t, IsBadRequest(wrappedErr))
+	assert.False(t, IsUnavailable(wrappedErr))
+
+	var respErr *ResponseError
+	require.True(t, errors.As(wrappedErr, &respErr))
+	assert.Equal(t, "org.apache.solr.common.SolrException: version conflict", respErr.Trace)
+
+	var gotSolrErr *SolrError
+	require.True(t, errors.As(wrappedErr, &gotSolrErr))
+	assert.Equal(t, solrErr, gotSolrErr)
+
+	t.Run("without response error", func(t *testing.T) {
+		solrErr := newSolrError(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+		assert.True(t, IsUnavailable(solrErr))
+		assert.Equal(t, "solr: status 503: Service Unavailable", solrErr.Error())
+	})
+
+	t.Run("helpers", func(t *testing.T) {
+		var tests = []struct {
+			statusCode int
+			is         func(error) bool
+		}{
+			{http.StatusBadRequest, IsBadRequest},
+			{http.StatusNotFound, IsNotFound},
+			{http.StatusConflict, IsConflict},
+			{http.StatusServiceUnavailable, IsUnavailable},
+		}
+
+		for _, test := range tests {
+			err := &SolrError{StatusCode: test.statusCode}
+			assert.True(t, test.is(err))
+			assert.False(t, test.is(&SolrError{StatusCode: http.StatusOK}))
+			assert.False(t, test.is(errors.New("an error")))
+		}
+	})
+}
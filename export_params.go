@@ -0,0 +1,153 @@
+package solr
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ExportParams is the export handler param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/exporting-result-sets.html
+type ExportParams struct {
+	query          string
+	sort           string
+	fields         []string
+	filters        []string
+	expr           StreamExpression
+	skipValidation bool
+}
+
+// NewExportParams returns a new ExportParams. The sort and the fields are required
+// and must have docValues e.g. NewExportParams("*:*", "id asc", "id", "name_s").
+func NewExportParams(query, sort string, fields ...string) *ExportParams {
+	return &ExportParams{query: query, sort: sort, fields: fields}
+}
+
+// Filters adds the filter queries
+func (p *ExportParams) Filters(filters ...string) *ExportParams {
+	p.filters = append(p.filters, filters...)
+	return p
+}
+
+// Expr sets the streaming expression applied to the exported docs, available since Solr 9
+// e.g. rollup(input(), over="cat_s", sum(price_f))
+//
+// Refer to https://solr.apache.org/guide/solr/latest/query-guide/exporting-result-sets.html#specifying-the-local-streaming-expression
+func (p *ExportParams) Expr(expr StreamExpression) *ExportParams {
+	p.expr = expr
+	return p
+}
+
+// SkipValidation skips checking that the sort and the fields have docValues
+func (p *ExportParams) SkipValidation() *ExportParams {
+	p.skipValidation = true
+	return p
+}
+
+// BuildParams builds the parameters
+func (p *ExportParams) BuildParams() string {
+	vals := &url.Values{}
+
+	if p.query != "" {
+		vals.Add("q", p.query)
+	}
+
+	if p.sort != "" {
+		vals.Add("sort", p.sort)
+	}
+
+	if len(p.fields) > 0 {
+		vals.Add("fl", strings.Join(p.fields, ","))
+	}
+
+	for _, fq := range p.filters {
+		vals.Add("fq", fq)
+	}
+
+	if p.expr != nil {
+		vals.Add("expr", p.expr.BuildExpression())
+	}
+
+	return vals.Encode()
+}
+
+// fieldNames returns the field names used by the sort and the fields
+func (p *ExportParams) fieldNames() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, field := range p.fields {
+		for _, name := range strings.Split(field, ",") {
+			add(name)
+		}
+	}
+
+	for _, clause := range strings.Split(p.sort, ",") {
+		// e.g. "price_f desc"
+		if parts := strings.Fields(clause); len(parts) > 0 {
+			add(parts[0])
+		}
+	}
+
+	return names
+}
+
+// validateDocValues checks that the fields have docValues. The fields are
+// looked up in the explicit fields, then in the dynamic fields.
+func validateDocValues(names []string, fields, dynamicFields []Field) error {
+	explicit := make(map[string]Field, len(fields))
+	for _, field := range fields {
+		explicit[field.Name] = field
+	}
+
+	// the longest dynamic field pattern wins, same as Solr
+	dynamicFields = append([]Field{}, dynamicFields...)
+	sort.SliceStable(dynamicFields, func(i, j int) bool {
+		return len(dynamicFields[i].Name) > len(dynamicFields[j].Name)
+	})
+
+	for _, name := range names {
+		field, ok := explicit[name]
+		if !ok {
+			field, ok = matchDynamicField(name, dynamicFields)
+		}
+
+		if !ok {
+			return fmt.Errorf("unknown field %q", name)
+		}
+
+		if !boolValue(field.DocValues) {
+			return fmt.Errorf("field %q doesn't have docValues", name)
+		}
+	}
+
+	return nil
+}
+
+// matchDynamicField returns the first dynamic field whose pattern matches the name
+func matchDynamicField(name string, dynamicFields []Field) (Field, bool) {
+	for _, field := range dynamicFields {
+		pattern := field.Name
+		switch {
+		case strings.HasPrefix(pattern, "*"):
+			if strings.HasSuffix(name, pattern[1:]) {
+				return field, true
+			}
+		case strings.HasSuffix(pattern, "*"):
+			if strings.HasPrefix(name, pattern[:len(pattern)-1]) {
+				return field, true
+			}
+		}
+	}
+
+	return Field{}, false
+}
@@ -0,0 +1,19 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildExportParams(t *testing.T) {
+	got := solr.NewExportParams("*:*", "id asc", "id", "price_f").
+		Filters("inStock:true").
+		Expr(solr.RawExpression(`rollup(input(), over="cat_s", sum(price_f))`)).
+		BuildParams()
+
+	expect := "expr=rollup%28input%28%29%2C+over%3D%22cat_s%22%2C+sum%28price_f%29%29&fl=id%2Cprice_f&fq=inStock%3Atrue&q=%2A%3A%2A&sort=id+asc"
+	assert.Equal(t, expect, got)
+}
@@ -0,0 +1,272 @@
+package solr
+
+// FieldBuilder is the field and dynamic field builder. The properties that are
+// not set are inherited from the field type.
+type FieldBuilder struct {
+	field Field
+}
+
+// NewFieldBuilder returns a new FieldBuilder e.g.
+//
+//	solr.NewFieldBuilder("title", "text_general").Indexed(true).Stored(false).BuildField()
+func NewFieldBuilder(name, typ string) *FieldBuilder {
+	return &FieldBuilder{field: Field{Name: name, Type: typ}}
+}
+
+// Default sets the default value
+func (b *FieldBuilder) Default(value string) *FieldBuilder {
+	b.field.Default = value
+	return b
+}
+
+// DocValues sets the docValues property
+func (b *FieldBuilder) DocValues(docValues bool) *FieldBuilder {
+	b.field.DocValues = Bool(docValues)
+	return b
+}
+
+// Indexed sets the indexed property
+func (b *FieldBuilder) Indexed(indexed bool) *FieldBuilder {
+	b.field.Indexed = Bool(indexed)
+	return b
+}
+
+// Stored sets the stored property
+func (b *FieldBuilder) Stored(stored bool) *FieldBuilder {
+	b.field.Stored = Bool(stored)
+	return b
+}
+
+// MultiValued sets the multiValued property
+func (b *FieldBuilder) MultiValued(multiValued bool) *FieldBuilder {
+	b.field.MultiValued = Bool(multiValued)
+	return b
+}
+
+// Required sets the required property
+func (b *FieldBuilder) Required(required bool) *FieldBuilder {
+	b.field.Required = Bool(required)
+	return b
+}
+
+// UseDocValuesAsStored sets the useDocValuesAsStored property
+func (b *FieldBuilder) UseDocValuesAsStored(useDocValuesAsStored bool) *FieldBuilder {
+	b.field.UseDocValuesAsStored = Bool(useDocValuesAsStored)
+	return b
+}
+
+// SortMissingFirst sets the sortMissingFirst property
+func (b *FieldBuilder) SortMissingFirst(sortMissingFirst bool) *FieldBuilder {
+	b.field.SortMissingFirst = Bool(sortMissingFirst)
+	return b
+}
+
+// SortMissingLast sets the sortMissingLast property
+func (b *FieldBuilder) SortMissingLast(sortMissingLast bool) *FieldBuilder {
+	b.field.SortMissingLast = Bool(sortMissingLast)
+	return b
+}
+
+// Uninvertible sets the uninvertible property
+func (b *FieldBuilder) Uninvertible(uninvertible bool) *FieldBuilder {
+	b.field.Uninvertible = Bool(uninvertible)
+	return b
+}
+
+// OmitNorms sets the omitNorms property
+func (b *FieldBuilder) OmitNorms(omitNorms bool) *FieldBuilder {
+	b.field.OmitNorms = Bool(omitNorms)
+	return b
+}
+
+// OmitTermFreqAndPositions sets the omitTermFreqAndPositions property
+func (b *FieldBuilder) OmitTermFreqAndPositions(omitTermFreqAndPositions bool) *FieldBuilder {
+	b.field.OmitTermFreqAndPositions = Bool(omitTermFreqAndPositions)
+	return b
+}
+
+// OmitPositions sets the omitPositions property
+func (b *FieldBuilder) OmitPositions(omitPositions bool) *FieldBuilder {
+	b.field.OmitPositions = Bool(omitPositions)
+	return b
+}
+
+// TermVectors sets the termVectors property
+func (b *FieldBuilder) TermVectors(termVectors bool) *FieldBuilder {
+	b.field.TermVectors = Bool(termVectors)
+	return b
+}
+
+// TermPositions sets the termPositions property
+func (b *FieldBuilder) TermPositions(termPositions bool) *FieldBuilder {
+	b.field.TermPositions = Bool(termPositions)
+	return b
+}
+
+// TermOffsets sets the termOffsets property
+func (b *FieldBuilder) TermOffsets(termOffsets bool) *FieldBuilder {
+	b.field.TermOffsets = Bool(termOffsets)
+	return b
+}
+
+// TermPayloads sets the termPayloads property
+func (b *FieldBuilder) TermPayloads(termPayloads bool) *FieldBuilder {
+	b.field.TermPayloads = Bool(termPayloads)
+	return b
+}
+
+// Large sets the large property
+func (b *FieldBuilder) Large(large bool) *FieldBuilder {
+	b.field.Large = Bool(large)
+	return b
+}
+
+// BuildField builds the field
+func (b *FieldBuilder) BuildField() Field {
+	return b.field
+}
+
+// FieldTypeBuilder is the field type builder
+type FieldTypeBuilder struct {
+	fieldType FieldType
+}
+
+// NewFieldTypeBuilder returns a new FieldTypeBuilder e.g.
+//
+//	solr.NewFieldTypeBuilder("text_en", "solr.TextField").
+//		Analyzer(solr.NewAnalyzer(solr.NewStandardTokenizer(), solr.NewLowerCaseFilter())).
+//		BuildFieldType()
+func NewFieldTypeBuilder(name, class string) *FieldTypeBuilder {
+	return &FieldTypeBuilder{fieldType: FieldType{Name: name, Class: class}}
+}
+
+// PositionIncrementGap sets the position increment gap of multi-valued fields
+func (b *FieldTypeBuilder) PositionIncrementGap(gap string) *FieldTypeBuilder {
+	b.fieldType.PositionIncrementGap = gap
+	return b
+}
+
+// Analyzer sets the analyzer used for both indexing and querying
+func (b *FieldTypeBuilder) Analyzer(analyzer *Analyzer) *FieldTypeBuilder {
+	b.fieldType.Analyzer = analyzer
+	return b
+}
+
+// IndexAnalyzer sets the analyzer used for indexing
+func (b *FieldTypeBuilder) IndexAnalyzer(analyzer *Analyzer) *FieldTypeBuilder {
+	b.fieldType.IndexAnalyzer = analyzer
+	return b
+}
+
+// QueryAnalyzer sets the analyzer used for querying
+func (b *FieldTypeBuilder) QueryAnalyzer(analyzer *Analyzer) *FieldTypeBuilder {
+	b.fieldType.QueryAnalyzer = analyzer
+	return b
+}
+
+// DocValues sets the docValues property
+func (b *FieldTypeBuilder) DocValues(docValues bool) *FieldTypeBuilder {
+	b.fieldType.DocValues = Bool(docValues)
+	return b
+}
+
+// Indexed sets the indexed property
+func (b *FieldTypeBuilder) Indexed(indexed bool) *FieldTypeBuilder {
+	b.fieldType.Indexed = Bool(indexed)
+	return b
+}
+
+// Stored sets the stored property
+func (b *FieldTypeBuilder) Stored(stored bool) *FieldTypeBuilder {
+	b.fieldType.Stored = Bool(stored)
+	return b
+}
+
+// MultiValued sets the multiValued property
+func (b *FieldTypeBuilder) MultiValued(multiValued bool) *FieldTypeBuilder {
+	b.fieldType.MultiValued = Bool(multiValued)
+	return b
+}
+
+// Required sets the required property
+func (b *FieldTypeBuilder) Required(required bool) *FieldTypeBuilder {
+	b.fieldType.Required = Bool(required)
+	return b
+}
+
+// UseDocValuesAsStored sets the useDocValuesAsStored property
+func (b *FieldTypeBuilder) UseDocValuesAsStored(useDocValuesAsStored bool) *FieldTypeBuilder {
+	b.fieldType.UseDocValuesAsStored = Bool(useDocValuesAsStored)
+	return b
+}
+
+// SortMissingFirst sets the sortMissingFirst property
+func (b *FieldTypeBuilder) SortMissingFirst(sortMissingFirst bool) *FieldTypeBuilder {
+	b.fieldType.SortMissingFirst = Bool(sortMissingFirst)
+	return b
+}
+
+// SortMissingLast sets the sortMissingLast property
+func (b *FieldTypeBuilder) SortMissingLast(sortMissingLast bool) *FieldTypeBuilder {
+	b.fieldType.SortMissingLast = Bool(sortMissingLast)
+	return b
+}
+
+// Uninvertible sets the uninvertible property
+func (b *FieldTypeBuilder) Uninvertible(uninvertible bool) *FieldTypeBuilder {
+	b.fieldType.Uninvertible = Bool(uninvertible)
+	return b
+}
+
+// OmitNorms sets the omitNorms property
+func (b *FieldTypeBuilder) OmitNorms(omitNorms bool) *FieldTypeBuilder {
+	b.fieldType.OmitNorms = Bool(omitNorms)
+	return b
+}
+
+// OmitTermFreqAndPositions sets the omitTermFreqAndPositions property
+func (b *FieldTypeBuilder) OmitTermFreqAndPositions(omitTermFreqAndPositions bool) *FieldTypeBuilder {
+	b.fieldType.OmitTermFreqAndPositions = Bool(omitTermFreqAndPositions)
+	return b
+}
+
+// OmitPositions sets the omitPositions property
+func (b *FieldTypeBuilder) OmitPositions(omitPositions bool) *FieldTypeBuilder {
+	b.fieldType.OmitPositions = Bool(omitPositions)
+	return b
+}
+
+// TermVectors sets the termVectors property
+func (b *FieldTypeBuilder) TermVectors(termVectors bool) *FieldTypeBuilder {
+	b.fieldType.TermVectors = Bool(termVectors)
+	return b
+}
+
+// TermPositions sets the termPositions property
+func (b *FieldTypeBuilder) TermPositions(termPositions bool) *FieldTypeBuilder {
+	b.fieldType.TermPositions = Bool(termPositions)
+	return b
+}
+
+// TermOffsets sets the termOffsets property
+func (b *FieldTypeBuilder) TermOffsets(termOffsets bool) *FieldTypeBuilder {
+	b.fieldType.TermOffsets = Bool(termOffsets)
+	return b
+}
+
+// TermPayloads sets the termPayloads property
+func (b *FieldTypeBuilder) TermPayloads(termPayloads bool) *FieldTypeBuilder {
+	b.fieldType.TermPayloads = Bool(termPayloads)
+	return b
+}
+
+// Large sets the large property
+func (b *FieldTypeBuilder) Large(large bool) *FieldTypeBuilder {
+	b.fieldType.Large = Bool(large)
+	return b
+}
+
+// BuildFieldType builds the field type
+func (b *FieldTypeBuilder) BuildFieldType() FieldType {
+	return b.fieldType
+}
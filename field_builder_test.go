@@ -0,0 +1,60 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestFieldBuilder(t *testing.T) {
+	got := solr.NewFieldBuilder("title", "text_general").
+		Default("untitled").
+		Indexed(true).
+		Stored(false).
+		MultiValued(false).
+		OmitNorms(true).
+		TermVectors(true).
+		BuildField()
+
+	expect := solr.Field{
+		Name:        "title",
+		Type:        "text_general",
+		Default:     "untitled",
+		Indexed:     solr.Bool(true),
+		Stored:      solr.Bool(false),
+		MultiValued: solr.Bool(false),
+		OmitNorms:   solr.Bool(true),
+		TermVectors: solr.Bool(true),
+	}
+	assert.Equal(t, expect, got)
+
+	assert.Equal(t, solr.Field{Name: "id", Type: "string"},
+		solr.NewFieldBuilder("id", "string").BuildField())
+}
+
+func TestFieldTypeBuilder(t *testing.T) {
+	analyzer := solr.NewAnalyzer(solr.NewStandardTokenizer(), solr.NewLowerCaseFilter())
+	got := solr.NewFieldTypeBuilder("text_en", "solr.TextField").
+		PositionIncrementGap("100").
+		IndexAnalyzer(analyzer).
+		QueryAnalyzer(analyzer).
+		DocValues(false).
+		SortMissingLast(true).
+		BuildFieldType()
+
+	expect := solr.FieldType{
+		Name:                 "text_en",
+		Class:                "solr.TextField",
+		PositionIncrementGap: "100",
+		IndexAnalyzer:        analyzer,
+		QueryAnalyzer:        analyzer,
+		DocValues:            solr.Bool(false),
+		SortMissingLast:      solr.Bool(true),
+	}
+	assert.Equal(t, expect, got)
+
+	assert.Equal(t, solr.FieldType{Name: "string", Class: "solr.StrField", Analyzer: analyzer},
+		solr.NewFieldTypeBuilder("string", "solr.StrField").Analyzer(analyzer).BuildFieldType())
+}
@@ -80,11 +80,7 @@ func newTestRunner(client solr.Client, collection string) func(t *testing.T) {
 					{
 						Class: "solr.ASCIIFoldingFilterFactory",
 					},
-					{
-						Class:       "solr.EdgeNGramFilterFactory",
-						MinGramSize: 1,
-						MaxGramSize: 20,
-					},
+					solr.NewEdgeNGramFilter(1, 20),
 				},
 			},
 			QueryAnalyzer: &solr.Analyzer{
@@ -98,10 +94,7 @@ func newTestRunner(client solr.Client, collection string) func(t *testing.T) {
 					{
 						Class: "solr.ASCIIFoldingFilterFactory",
 					},
-					{
-						Class:    "solr.SynonymGraphFilterFactory",
-						Synonyms: "synonyms.txt",
-					},
+					solr.NewSynonymGraphFilter("synonyms.txt"),
 				},
 			},
 		}
@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -17,6 +20,8 @@ type JSONClient struct {
 	baseURL string
 	// reqSender is the request sender
 	reqSender RequestSender
+	// lb is the load-balancing request sender created by NewLBJSONClient
+	lb *LBRequestSender
 }
 
 var _ Client = (*JSONClient)(nil)
@@ -29,6 +34,25 @@ func NewJSONClient(baseURL string) *JSONClient {
 	}
 }
 
+// NewLBJSONClient takes the base URLs of the Solr nodes and returns a new
+// JSONClient that load-balances the requests across the nodes.
+// The client must be closed to stop pinging the failed nodes.
+func NewLBJSONClient(baseURLs ...string) *JSONClient {
+	lb := NewLBRequestSender(NewDefaultRequestSender(), baseURLs...)
+	return &JSONClient{
+		reqSender: lb,
+		lb:        lb,
+	}
+}
+
+// Close stops the zombie checker of a client returned by NewLBJSONClient.
+// It's a no-op for the other clients.
+func (c *JSONClient) Close() {
+	if c.lb != nil {
+		c.lb.Close()
+	}
+}
+
 // WithRequestSender overrides the default request sender
 func (c *JSONClient) WithRequestSender(reqSender RequestSender) *JSONClient {
 	c.reqSender = reqSender
@@ -73,6 +97,45 @@ func (c *JSONClient) DeleteCollection(ctx context.Context, params *CollectionPar
 	return nil
 }
 
+// ReloadCollection reloads a collection e.g. after changing a managed resource.
+//
+// Refer to https://solr.apache.org/guide/8_8/collection-management.html#reload
+func (c *JSONClient) ReloadCollection(ctx context.Context, params *CollectionParams) error {
+	urlStr := fmt.Sprintf("%s/solr/admin/collections?action=RELOAD&"+params.BuildParams(), c.baseURL)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	var resp BaseResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
+// ClusterStatus returns the status of the SolrCloud cluster including the
+// collections, shards, replicas, aliases and the live nodes.
+//
+// Refer to https://solr.apache.org/guide/8_8/cluster-node-management.html#clusterstatus
+func (c *JSONClient) ClusterStatus(ctx context.Context) (*ClusterStatusResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/admin/collections?action=CLUSTERSTATUS", c.baseURL)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp ClusterStatusResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
 // CoreStatus returns the status of all running Solr cores, or status for only the named core.
 //
 // Refer to https://solr.apache.org/guide/8_8/coreadmin-api.html#coreadmin-status
@@ -130,6 +193,25 @@ func (c *JSONClient) UnloadCore(ctx context.Context, params *CoreParams) error {
 	return nil
 }
 
+// ReloadCore reloads a core e.g. after changing a managed resource.
+//
+// Refer to https://solr.apache.org/guide/8_8/coreadmin-api.html#coreadmin-reload
+func (c *JSONClient) ReloadCore(ctx context.Context, params *CoreParams) error {
+	urlStr := fmt.Sprintf("%s/solr/admin/cores?action=RELOAD&"+params.BuildParams(), c.baseURL)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	var resp BaseResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
 // Query sends a query request to query API.
 //
 // Refer to https://solr.apache.org/guide/8_8/json-request-api.html
@@ -174,9 +256,81 @@ func (c *JSONClient) Update(ctx context.Context, collection string, mimeType Mim
 	return &resp, nil
 }
 
-// Commit commits the last update.
-func (c *JSONClient) Commit(ctx context.Context, collection string) error {
-	urlStr := fmt.Sprintf("%s/solr/%s/update?commit=true", c.baseURL, collection)
+// AddDocuments encodes the documents using EncodeDocument and adds them to the index
+func (c *JSONClient) AddDocuments(ctx context.Context, collection string, docs ...interface{}) (*UpdateResponse, error) {
+	body, err := encodeDocuments(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Update(ctx, collection, JSON, body)
+}
+
+// encodeDocuments encodes the documents into a JSON array
+func encodeDocuments(docs []interface{}) (io.Reader, error) {
+	encoded := make([]M, len(docs))
+	for i, doc := range docs {
+		var err error
+		encoded[i], err = EncodeDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, wrapErr(err, "marshal documents")
+	}
+
+	return bytes.NewReader(b), nil
+}
+
+// Commit commits the last update. Only the first CommitOptions is used.
+func (c *JSONClient) Commit(ctx context.Context, collection string, opts ...*CommitOptions) error {
+	vals := &url.Values{}
+	vals.Add("commit", "true")
+	if len(opts) > 0 && opts[0] != nil {
+		opts[0].addFlags(vals)
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/update?%s", c.baseURL, collection, vals.Encode())
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	var resp UpdateResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
+// DeleteByIDs deletes the documents with the given ids. Only the first CommitOptions is used.
+func (c *JSONClient) DeleteByIDs(ctx context.Context, collection string, ids []string, opts ...*CommitOptions) (*UpdateResponse, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no ids to delete")
+	}
+
+	return c.updateCommand(ctx, collection, M{"delete": ids}, opts)
+}
+
+// DeleteByQuery deletes the documents matching the query. Only the first CommitOptions is used.
+func (c *JSONClient) DeleteByQuery(ctx context.Context, collection string, query QueryParser, opts ...*CommitOptions) (*UpdateResponse, error) {
+	return c.updateCommand(ctx, collection, M{"delete": M{"query": query.BuildParser()}}, opts)
+}
+
+// Optimize merges the index segments down to maxSegments, a maxSegments of 0 uses the Solr default
+func (c *JSONClient) Optimize(ctx context.Context, collection string, maxSegments int) error {
+	vals := &url.Values{}
+	vals.Add("optimize", "true")
+	if maxSegments > 0 {
+		vals.Add("maxSegments", strconv.Itoa(maxSegments))
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/update?%s", c.baseURL, collection, vals.Encode())
 	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
 	if err != nil {
 		return wrapErr(err, "send request")
@@ -191,6 +345,118 @@ func (c *JSONClient) Commit(ctx context.Context, collection string) error {
 	return nil
 }
 
+// Rollback discards the uncommitted updates
+func (c *JSONClient) Rollback(ctx context.Context, collection string) error {
+	_, err := c.updateCommand(ctx, collection, M{"rollback": M{}}, nil)
+	return err
+}
+
+// updateCommand sends the JSON update command
+func (c *JSONClient) updateCommand(ctx context.Context, collection string, command M, opts []*CommitOptions) (*UpdateResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/update", c.baseURL, collection)
+	if len(opts) > 0 && opts[0] != nil {
+		urlStr += "?" + opts[0].BuildParams()
+	}
+
+	buf := &bytes.Buffer{}
+	err := json.NewEncoder(buf).Encode(command)
+	if err != nil {
+		return nil, wrapErr(err, "encode request body")
+	}
+
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, JSON.String(), buf)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp UpdateResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// Stream sends the streaming expression to the stream handler and returns an iterator over the tuples.
+// The iterator must be closed if it's not iterated until the end.
+func (c *JSONClient) Stream(ctx context.Context, collection string, expr StreamExpression) (*TupleIterator, error) {
+	form := url.Values{}
+	form.Set("expr", expr.BuildExpression())
+
+	urlStr := fmt.Sprintf("%s/solr/%s/stream", c.baseURL, collection)
+	body, err := c.postForm(ctx, urlStr, form.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	return newTupleIterator(body), nil
+}
+
+// SQL sends the SQL statement to the SQL handler and returns an iterator over the result tuples.
+// Numbers in the tuples are decoded as json.Number to keep the integers apart from the floats.
+// The iterator must be closed if it's not iterated until the end.
+func (c *JSONClient) SQL(ctx context.Context, collection string, params *SQLParams) (*TupleIterator, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/sql", c.baseURL, collection)
+	body, err := c.postForm(ctx, urlStr, params.BuildParams())
+	if err != nil {
+		return nil, err
+	}
+
+	it := newTupleIterator(body)
+	it.dec.UseNumber()
+	return it, nil
+}
+
+// Export sends the query to the export handler and returns an iterator over the docs.
+// The sort and the fields are checked for docValues using the schema unless the
+// validation is skipped. Numbers in the docs are decoded as json.Number so that the long
+// values e.g. _version_ keep their precision when reindexed.
+// The iterator must be closed if it's not iterated until the end.
+func (c *JSONClient) Export(ctx context.Context, collection string, params *ExportParams) (*TupleIterator, error) {
+	if !params.skipValidation {
+		opts := NewSchemaOptions().ShowDefaults()
+		fields, err := c.GetFields(ctx, collection, opts)
+		if err != nil {
+			return nil, wrapErr(err, "get fields")
+		}
+
+		dynamicFields, err := c.GetDynamicFields(ctx, collection, opts)
+		if err != nil {
+			return nil, wrapErr(err, "get dynamic fields")
+		}
+
+		err = validateDocValues(params.fieldNames(), fields, dynamicFields)
+		if err != nil {
+			return nil, wrapErr(err, "validate export params")
+		}
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/export", c.baseURL, collection)
+	body, err := c.postForm(ctx, urlStr, params.BuildParams())
+	if err != nil {
+		return nil, err
+	}
+
+	return newExportIterator(body), nil
+}
+
+// postForm posts the encoded form and returns the response body
+func (c *JSONClient) postForm(ctx context.Context, urlStr, form string) (io.ReadCloser, error) {
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr,
+		FormURLEncoded.String(), strings.NewReader(form))
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		defer httpResp.Body.Close()
+		return nil, wrapErr(readErrorResponse(httpResp), "read response")
+	}
+
+	return httpResp.Body, nil
+}
+
 // AddFields adds new field definitions to the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-new-field
@@ -268,6 +534,252 @@ func (c *JSONClient) DeleteCopyFields(ctx context.Context, collection string, co
 	return c.modifySchema(ctx, collection, "delete-copy-field", copyFields)
 }
 
+// GetSchema returns the whole schema.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#retrieve-the-entire-schema
+func (c *JSONClient) GetSchema(ctx context.Context, collection string) (*Schema, error) {
+	var resp struct {
+		*BaseResponse
+		Schema *Schema `json:"schema"`
+	}
+	err := c.getSchema(ctx, collection, "", url.Values{}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Schema, nil
+}
+
+// GetSchemaXML returns the whole schema in the schema.xml format.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#retrieve-the-entire-schema
+func (c *JSONClient) GetSchemaXML(ctx context.Context, collection string) ([]byte, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema?wt=schema.xml", c.baseURL, collection)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, XML.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		return nil, wrapErr(readErrorResponse(httpResp), "read response")
+	}
+
+	b, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return b, nil
+}
+
+// GetFields returns the fields.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+func (c *JSONClient) GetFields(ctx context.Context, collection string, opts ...*SchemaOptions) ([]Field, error) {
+	var resp struct {
+		*BaseResponse
+		Fields []Field `json:"fields"`
+	}
+	err := c.getSchema(ctx, collection, "/fields", schemaParams(opts), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Fields, nil
+}
+
+// GetField returns the field. If includeDynamic is true and there's no field with
+// the name, the dynamic field rule that matches the name is returned instead.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+func (c *JSONClient) GetField(ctx context.Context, collection, name string, includeDynamic bool, opts ...*SchemaOptions) (*Field, error) {
+	params := schemaParams(opts)
+	if includeDynamic {
+		params.Set("includeDynamic", "true")
+	}
+
+	var resp struct {
+		*BaseResponse
+		Field *Field `json:"field"`
+	}
+	err := c.getSchema(ctx, collection, "/fields/"+url.PathEscape(name), params, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Field, nil
+}
+
+// GetDynamicFields returns the dynamic field rules.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-dynamic-fields
+func (c *JSONClient) GetDynamicFields(ctx context.Context, collection string, opts ...*SchemaOptions) ([]Field, error) {
+	var resp struct {
+		*BaseResponse
+		DynamicFields []Field `json:"dynamicFields"`
+	}
+	err := c.getSchema(ctx, collection, "/dynamicfields", schemaParams(opts), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DynamicFields, nil
+}
+
+// GetFieldTypes returns the field types.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-field-types
+func (c *JSONClient) GetFieldTypes(ctx context.Context, collection string, opts ...*SchemaOptions) ([]FieldType, error) {
+	var resp struct {
+		*BaseResponse
+		FieldTypes []FieldType `json:"fieldTypes"`
+	}
+	err := c.getSchema(ctx, collection, "/fieldtypes", schemaParams(opts), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.FieldTypes, nil
+}
+
+// GetCopyFields returns the copy field rules.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-copy-fields
+func (c *JSONClient) GetCopyFields(ctx context.Context, collection string) ([]CopyField, error) {
+	var resp struct {
+		*BaseResponse
+		CopyFields []CopyField `json:"copyFields"`
+	}
+	err := c.getSchema(ctx, collection, "/copyfields", url.Values{}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CopyFields, nil
+}
+
+// GetUniqueKey returns the unique key field name.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-uniquekey
+func (c *JSONClient) GetUniqueKey(ctx context.Context, collection string) (string, error) {
+	var resp struct {
+		*BaseResponse
+		UniqueKey string `json:"uniqueKey"`
+	}
+	err := c.getSchema(ctx, collection, "/uniquekey", url.Values{}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.UniqueKey, nil
+}
+
+// GetSimilarity returns the global similarity class and its params.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-global-similarity
+func (c *JSONClient) GetSimilarity(ctx context.Context, collection string) (M, error) {
+	var resp struct {
+		*BaseResponse
+		Similarity M `json:"similarity"`
+	}
+	err := c.getSchema(ctx, collection, "/similarity", url.Values{}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Similarity, nil
+}
+
+// GetSchemaVersion returns the schema version.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-the-schema-version
+func (c *JSONClient) GetSchemaVersion(ctx context.Context, collection string) (float64, error) {
+	var resp struct {
+		*BaseResponse
+		Version float64 `json:"version"`
+	}
+	err := c.getSchema(ctx, collection, "/version", url.Values{}, &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Version, nil
+}
+
+// getSchema reads the schema path into v
+func (c *JSONClient) getSchema(ctx context.Context, collection, path string, params url.Values, v interface{}) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema%s", c.baseURL, collection, path)
+	if encoded := params.Encode(); encoded != "" {
+		urlStr += "?" + encoded
+	}
+
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	err = readResponse(httpResp, v)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
+// schemaParams merges the schema options
+func schemaParams(opts []*SchemaOptions) url.Values {
+	params := url.Values{}
+	for _, opt := range opts {
+		if opt != nil && opt.showDefaults {
+			params.Set("showDefaults", "true")
+		}
+	}
+
+	return params
+}
+
+// ModifySchema sends the commands in a single request. The commands are executed
+// in order and if one fails, none of the changes are applied.
+// The errors of the individual commands are available in ResponseError.Details.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#multiple-commands-in-a-single-post
+func (c *JSONClient) ModifySchema(ctx context.Context, collection string, commands ...SchemaCommand) error {
+	if len(commands) == 0 {
+		return errors.New("no commands")
+	}
+
+	// written by hand since the same command can appear multiple times
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, command := range commands {
+		b, err := json.Marshal(command.Body)
+		if err != nil {
+			return wrapErr(err, "marshal command")
+		}
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(buf, "%q:%s", command.Name, b)
+	}
+	buf.WriteByte('}')
+
+	urlStr := fmt.Sprintf("%s/solr/%s/schema", c.baseURL, collection)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, JSON.String(), buf)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	var resp BaseResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
 func (c *JSONClient) modifySchema(ctx context.Context, collection, command string, body interface{}) error {
 	urlStr := fmt.Sprintf("%s/solr/%s/schema", c.baseURL, collection)
 	return c.postJSON(ctx, urlStr, M{command: body})
@@ -315,18 +827,210 @@ func (c *JSONClient) UnsetProperty(ctx context.Context, collection string, prope
 	return c.postJSON(ctx, urlStr, M{"unset-property": property.Name})
 }
 
+// SetUserProperties sets user-defined properties that can be used for ${...} substitutions in solrconfig.xml.
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-user-defined-properties
+func (c *JSONClient) SetUserProperties(ctx context.Context, collection string, properties M) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/config", c.baseURL, collection)
+	return c.postJSON(ctx, urlStr, M{"set-user-property": properties})
+}
+
+// UnsetUserProperty removes a user-defined property set via SetUserProperties.
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-user-defined-properties
+func (c *JSONClient) UnsetUserProperty(ctx context.Context, collection, name string) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/config", c.baseURL, collection)
+	return c.postJSON(ctx, urlStr, M{"unset-user-property": name})
+}
+
+// GetUserProperties returns the user-defined properties from the config overlay.
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-user-defined-properties
+func (c *JSONClient) GetUserProperties(ctx context.Context, collection string) (M, error) {
+	resp, err := c.GetConfigOverlay(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Overlay == nil || resp.Overlay.UserProps == nil {
+		return M{}, nil
+	}
+
+	return resp.Overlay.UserProps, nil
+}
+
+// GetConfig returns the effective config. If section is not empty e.g. "requestHandler"
+// or "query", only that section of the config is returned.
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#retrieving-the-config
+func (c *JSONClient) GetConfig(ctx context.Context, collection, section string) (*ConfigResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/config", c.baseURL, collection)
+	if section != "" {
+		urlStr += "/" + url.PathEscape(section)
+	}
+
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp ConfigResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// GetConfigOverlay returns the changes made via the Config API i.e. configoverlay.json
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#config-overlay
+func (c *JSONClient) GetConfigOverlay(ctx context.Context, collection string) (*ConfigOverlayResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/config/overlay", c.baseURL, collection)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp ConfigOverlayResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// SetParams creates or overwrites the paramsets.
+//
+// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#setting-request-parameters
+func (c *JSONClient) SetParams(ctx context.Context, collection string, paramSets ...ParamSet) error {
+	return c.modifyParams(ctx, collection, "set", paramSets)
+}
+
+// UpdateParams merges the params into the existing paramsets.
+//
+// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#setting-request-parameters
+func (c *JSONClient) UpdateParams(ctx context.Context, collection string, paramSets ...ParamSet) error {
+	return c.modifyParams(ctx, collection, "update", paramSets)
+}
+
+// DeleteParams deletes the paramsets.
+//
+// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#setting-request-parameters
+func (c *JSONClient) DeleteParams(ctx context.Context, collection string, names ...string) error {
+	if len(names) == 0 {
+		return errors.New("no paramsets")
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/config/params", c.baseURL, collection)
+	return c.postJSON(ctx, urlStr, M{"delete": names})
+}
+
+// GetParams returns the paramsets sorted by name. If names is not empty, only those paramsets are returned.
+//
+// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html#viewing-expanded-paramsets-and-effective-parameters-with-requesthandlers
+func (c *JSONClient) GetParams(ctx context.Context, collection string, names ...string) ([]ParamSet, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/config/params", c.baseURL, collection)
+	if len(names) == 1 {
+		urlStr += "/" + url.PathEscape(names[0])
+	}
+
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp struct {
+		*BaseResponse
+		Response struct {
+			Params map[string]M `json:"params"`
+		} `json:"response"`
+	}
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	paramSets := []ParamSet{}
+	for name, m := range resp.Response.Params {
+		if len(wanted) > 0 && !wanted[name] {
+			continue
+		}
+
+		paramSets = append(paramSets, newParamSet(name, m))
+	}
+
+	sort.Slice(paramSets, func(i, j int) bool {
+		return paramSets[i].Name < paramSets[j].Name
+	})
+
+	return paramSets, nil
+}
+
+func (c *JSONClient) modifyParams(ctx context.Context, collection, action string, paramSets []ParamSet) error {
+	if len(paramSets) == 0 {
+		return errors.New("no paramsets")
+	}
+
+	m := M{}
+	for _, ps := range paramSets {
+		m[ps.Name] = ps.BuildParamSet()
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/config/params", c.baseURL, collection)
+	return c.postJSON(ctx, urlStr, M{action: m})
+}
+
 // AddComponents adds a component (request handler, search component, init params, etc.) to configoverlay.json.
 //
 // Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
 func (c *JSONClient) AddComponents(ctx context.Context, collection string, components ...*Component) error {
+	return c.modifyComponents(ctx, collection, "add", components)
+}
+
+// UpdateComponents overwrites existing settings from configoverlay.json.
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
+func (c *JSONClient) UpdateComponents(ctx context.Context, collection string, components ...*Component) error {
+	return c.modifyComponents(ctx, collection, "update", components)
+}
+
+// DeleteComponents removes settings from configoverlay.json. Only the component type and name are used.
+//
+// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
+func (c *JSONClient) DeleteComponents(ctx context.Context, collection string, components ...*Component) error {
+	return c.modifyComponents(ctx, collection, "delete", components)
+}
+
+// modifyComponents sends the component commands in a single request. The commands
+// are written by hand since the same command can appear multiple times.
+// The errors of the individual commands are available in ResponseError.Details.
+func (c *JSONClient) modifyComponents(ctx context.Context, collection, action string, components []*Component) error {
+	if len(components) == 0 {
+		return errors.New("no components")
+	}
+
 	commands := []string{}
 	for _, comp := range components {
-		b, err := json.Marshal(comp.BuildComponent())
+		var body interface{} = comp.BuildComponent()
+		if action == "delete" {
+			// delete commands only take the component name
+			body = comp.name
+		}
+
+		b, err := json.Marshal(body)
 		if err != nil {
 			return wrapErr(err, "marshal component")
 		}
 
-		command := fmt.Sprintf("%q:%s", "add-"+comp.ct.String(), string(b))
+		command := fmt.Sprintf("%q:%s", action+"-"+comp.ct.String(), string(b))
 		commands = append(commands, command)
 	}
 
@@ -345,21 +1049,271 @@ func (c *JSONClient) AddComponents(ctx context.Context, collection string, compo
 	}
 
 	return nil
+}
+
+// ListManagedResources returns the resources registered with the REST manager.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#restmanager-endpoint
+func (c *JSONClient) ListManagedResources(ctx context.Context, collection string) ([]ManagedResource, error) {
+	var resp struct {
+		*BaseResponse
+		ManagedResources []ManagedResource `json:"managedResources"`
+	}
+	err := c.getSchema(ctx, collection, "/managed", url.Values{}, &resp)
+	if err != nil {
+		return nil, err
+	}
 
+	return resp.ManagedResources, nil
 }
 
-// UpdateComponents overwrites existing settings from configoverlay.json.
+// GetStopwords returns the managed stopwords list.
 //
-// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
-func (c *JSONClient) UpdateComponents(ctx context.Context, collection string, components ...*Component) error {
-	return errors.New("not implemented")
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+func (c *JSONClient) GetStopwords(ctx context.Context, collection, name string) (*ManagedWordSet, error) {
+	var resp struct {
+		*BaseResponse
+		WordSet *ManagedWordSet `json:"wordSet"`
+	}
+	err := c.managedResource(ctx, http.MethodGet, collection, managedPath("stopwords", name), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.WordSet, nil
 }
 
-// DeleteComponents removes settings from configoverlay.json
+// HasStopword returns true if the word is in the managed stopwords list.
 //
-// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
-func (c *JSONClient) DeleteComponents(ctx context.Context, collection string, components ...*Component) error {
-	return errors.New("not implemented")
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+func (c *JSONClient) HasStopword(ctx context.Context, collection, name, word string) (bool, error) {
+	var resp BaseResponse
+	err := c.managedResource(ctx, http.MethodGet, collection, managedPath("stopwords", name, word), nil, &resp)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// AddStopwords adds the words to the managed stopwords list.
+// The collection must be reloaded for the changes to take effect.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+func (c *JSONClient) AddStopwords(ctx context.Context, collection, name string, words ...string) error {
+	if len(words) == 0 {
+		return errors.New("no stopwords")
+	}
+
+	var resp BaseResponse
+	return c.managedResource(ctx, http.MethodPut, collection, managedPath("stopwords", name), words, &resp)
+}
+
+// DeleteStopword removes the word from the managed stopwords list.
+// The collection must be reloaded for the changes to take effect.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+func (c *JSONClient) DeleteStopword(ctx context.Context, collection, name, word string) error {
+	var resp BaseResponse
+	return c.managedResource(ctx, http.MethodDelete, collection, managedPath("stopwords", name, word), nil, &resp)
+}
+
+// SetStopwordsIgnoreCase sets the ignoreCase init arg of the managed stopwords list.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+func (c *JSONClient) SetStopwordsIgnoreCase(ctx context.Context, collection, name string, ignoreCase bool) error {
+	var resp BaseResponse
+	return c.managedResource(ctx, http.MethodPut, collection, managedPath("stopwords", name),
+		M{"initArgs": M{"ignoreCase": ignoreCase}}, &resp)
+}
+
+// GetSynonyms returns the managed synonym map.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+func (c *JSONClient) GetSynonyms(ctx context.Context, collection, name string) (*ManagedSynonymMap, error) {
+	var resp struct {
+		*BaseResponse
+		SynonymMappings *ManagedSynonymMap `json:"synonymMappings"`
+	}
+	err := c.managedResource(ctx, http.MethodGet, collection, managedPath("synonyms", name), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SynonymMappings, nil
+}
+
+// GetSynonym returns the synonyms of the term in the managed synonym map.
+// Use IsNotFound to check if the term is not mapped.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+func (c *JSONClient) GetSynonym(ctx context.Context, collection, name, term string) ([]string, error) {
+	// the response is keyed by the term e.g. {"mad":["angry","upset"]}
+	var resp map[string]json.RawMessage
+	err := c.managedResource(ctx, http.MethodGet, collection, managedPath("synonyms", name, term), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	delete(resp, "responseHeader")
+
+	raw, ok := resp[term]
+	if !ok && len(resp) == 1 {
+		// the term is lower cased if ignoreCase is true
+		for _, v := range resp {
+			raw = v
+		}
+	}
+
+	synonyms := []string{}
+	if len(raw) > 0 {
+		err = json.Unmarshal(raw, &synonyms)
+		if err != nil {
+			return nil, wrapErr(err, "decode synonyms")
+		}
+	}
+
+	return synonyms, nil
+}
+
+// AddSynonyms adds the synonym mappings to the managed synonym map. The synonyms
+// are merged with the existing synonyms of a term, use ReplaceSynonyms to overwrite them.
+// The collection must be reloaded for the changes to take effect.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+func (c *JSONClient) AddSynonyms(ctx context.Context, collection, name string, mappings map[string][]string) error {
+	if len(mappings) == 0 {
+		return errors.New("no synonyms")
+	}
+
+	var resp BaseResponse
+	return c.managedResource(ctx, http.MethodPut, collection, managedPath("synonyms", name), mappings, &resp)
+}
+
+// DeleteSynonym removes the term from the managed synonym map.
+// The collection must be reloaded for the changes to take effect.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+func (c *JSONClient) DeleteSynonym(ctx context.Context, collection, name, term string) error {
+	var resp BaseResponse
+	return c.managedResource(ctx, http.MethodDelete, collection, managedPath("synonyms", name, term), nil, &resp)
+}
+
+// ReplaceSynonyms replaces the whole managed synonym map with the mappings. The terms
+// that are not in the mappings or whose synonyms changed are deleted, then the new
+// and the changed mappings are added. The changes are not atomic, if a request fails
+// the synonym map is left partially updated and ReplaceSynonyms can be retried.
+// The collection must be reloaded for the changes to take effect.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+func (c *JSONClient) ReplaceSynonyms(ctx context.Context, collection, name string, mappings map[string][]string) error {
+	current, err := c.GetSynonyms(ctx, collection, name)
+	if err != nil {
+		return wrapErr(err, "get synonyms")
+	}
+
+	live := map[string][]string{}
+	if current != nil && current.ManagedMap != nil {
+		live = current.ManagedMap
+	}
+
+	terms := make([]string, 0, len(live))
+	for term := range live {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	for _, term := range terms {
+		synonyms, ok := mappings[term]
+		if ok && equalSynonyms(live[term], synonyms) {
+			continue
+		}
+
+		err = c.DeleteSynonym(ctx, collection, name, term)
+		if err != nil {
+			return wrapErr(err, "delete synonym "+term)
+		}
+	}
+
+	changed := map[string][]string{}
+	for term, synonyms := range mappings {
+		if liveSynonyms, ok := live[term]; ok && equalSynonyms(liveSynonyms, synonyms) {
+			continue
+		}
+		changed[term] = synonyms
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	return c.AddSynonyms(ctx, collection, name, changed)
+}
+
+// SetSynonymsIgnoreCase sets the ignoreCase init arg of the managed synonym map.
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+func (c *JSONClient) SetSynonymsIgnoreCase(ctx context.Context, collection, name string, ignoreCase bool) error {
+	var resp BaseResponse
+	return c.managedResource(ctx, http.MethodPut, collection, managedPath("synonyms", name),
+		M{"initArgs": M{"ignoreCase": ignoreCase}}, &resp)
+}
+
+// managedResource sends a request to the managed resource path and reads the response into v
+func (c *JSONClient) managedResource(ctx context.Context, method, collection, path string, reqBody, v interface{}) error {
+	var body io.Reader
+	if reqBody != nil {
+		buf := &bytes.Buffer{}
+		err := json.NewEncoder(buf).Encode(reqBody)
+		if err != nil {
+			return wrapErr(err, "encode request body")
+		}
+		body = buf
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/analysis/%s", c.baseURL, collection, path)
+	httpResp, err := c.reqSender.SendRequest(ctx, method, urlStr, JSON.String(), body)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	err = readResponse(httpResp, v)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
+// managedPath returns the path of the managed resource e.g. "synonyms/english/mad"
+func managedPath(kind, name string, child ...string) string {
+	path := kind + "/" + url.PathEscape(name)
+	for _, p := range child {
+		path += "/" + url.PathEscape(p)
+	}
+
+	return path
+}
+
+// equalSynonyms returns true if the synonyms are the same regardless of the order
+func equalSynonyms(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
 }
 
 // Suggest queries the suggest endpoint.
@@ -382,6 +1336,12 @@ func (c *JSONClient) Suggest(ctx context.Context, collection string, params *Sug
 }
 
 func readResponse(resp *http.Response, v interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return readErrorResponse(resp)
+	}
+
 	contentType := resp.Header.Get("content-type")
 	if strings.Contains(contentType, "text/html") {
 		b, err := io.ReadAll(resp.Body)
@@ -397,10 +1357,35 @@ func readResponse(resp *http.Response, v interface{}) error {
 		return wrapErr(err, "decode json response")
 	}
 
-	val, ok := v.(*BaseResponse)
-	if ok && resp.StatusCode > http.StatusOK {
-		return val.Error
+	return nil
+}
+
+// readErrorResponse reads the error response into a SolrError
+func readErrorResponse(resp *http.Response) error {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return wrapErr(err, "read error response")
 	}
 
-	return nil
+	var baseResp struct {
+		BaseResponse
+		// ErrorMessages is the errors of the Config and Schema API commands
+		ErrorMessages []CommandError `json:"errorMessages"`
+	}
+	if json.Unmarshal(b, &baseResp) != nil || baseResp.Error == nil {
+		// not a json error response e.g. an html error page
+		// from the servlet container, use the raw body instead
+		msg := strings.TrimSpace(string(b))
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+
+		baseResp.Error = &ResponseError{Code: resp.StatusCode, Msg: msg}
+	}
+
+	if len(baseResp.Error.Details) == 0 {
+		baseResp.Error.Details = baseResp.ErrorMessages
+	}
+
+	return newSolrError(resp, baseResp.Error)
 }
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -87,6 +88,54 @@ func TestJSONClientMock(t *testing.T) {
 			err = clientThatErrors.DeleteCollection(ctx, params)
 			assert.ErrorIs(t, err, errSendRequest)
 		})
+		t.Run("reload collection", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/admin/collections",
+				func(r *http.Request) (*http.Response, error) {
+					query := "action=RELOAD&name=mycollection"
+					gotQuery := r.URL.Query().Encode()
+					if gotQuery != query {
+						return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+					}
+
+					return httpmock.NewJsonResponse(http.StatusOK, M{})
+				},
+			)
+
+			params := NewCollectionParams().
+				Name("mycollection")
+			err := client.ReloadCollection(ctx, params)
+			assert.NoError(t, err)
+
+			err = clientThatErrors.ReloadCollection(ctx, params)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+	})
+
+	t.Run("cluster status", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/admin/collections",
+			func(r *http.Request) (*http.Response, error) {
+				query := "action=CLUSTERSTATUS"
+				gotQuery := r.URL.Query().Encode()
+				if gotQuery != query {
+					return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+				}
+
+				return httpmock.NewJsonResponse(http.StatusOK, M{
+					"cluster": M{"live_nodes": []string{"localhost:8983_solr"}},
+				})
+			},
+		)
+
+		resp, err := client.ClusterStatus(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"localhost:8983_solr"}, resp.Cluster.LiveNodes)
+
+		_, err = clientThatErrors.ClusterStatus(ctx)
+		assert.ErrorIs(t, err, errSendRequest)
 	})
 
 	t.Run("core admin", func(t *testing.T) {
@@ -157,6 +206,29 @@ func TestJSONClientMock(t *testing.T) {
 			assert.NoError(t, err)
 
 		})
+
+		t.Run("reload core", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/admin/cores",
+				func(r *http.Request) (*http.Response, error) {
+					query := "action=RELOAD&core=mycore"
+					gotQuery := r.URL.Query().Encode()
+					if gotQuery != query {
+						return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+					}
+
+					return httpmock.NewJsonResponse(http.StatusOK, M{})
+				},
+			)
+
+			params := NewCoreParams("mycore")
+			err := client.ReloadCore(ctx, params)
+			assert.NoError(t, err)
+
+			err = clientThatErrors.ReloadCore(ctx, params)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
 	})
 
 	t.Run("query", func(t *testing.T) {
@@ -228,6 +300,130 @@ func TestJSONClientMock(t *testing.T) {
 		assert.ErrorIs(t, err, errSendRequest)
 	})
 
+	t.Run("delete, optimize and rollback", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/update",
+			func(r *http.Request) (*http.Response, error) {
+				var body M
+				err := json.NewDecoder(r.Body).Decode(&body)
+				if err != nil {
+					return nil, err
+				}
+
+				switch {
+				case reflect.DeepEqual(body, M{"delete": []interface{}{"1", "2"}}):
+					if r.URL.RawQuery != "commitWithin=1000" {
+						return nil, fmt.Errorf("unexpected params: %s", r.URL.RawQuery)
+					}
+				case reflect.DeepEqual(body, M{"delete": map[string]interface{}{"query": "{!lucene df=name v=product}"}}):
+					if r.URL.RawQuery != "commit=true&softCommit=true" {
+						return nil, fmt.Errorf("unexpected params: %s", r.URL.RawQuery)
+					}
+				case reflect.DeepEqual(body, M{"delete": map[string]interface{}{"query": "*:*"}}),
+					reflect.DeepEqual(body, M{"rollback": map[string]interface{}{}}):
+				default:
+					return nil, fmt.Errorf("unexpected request body: %v", body)
+				}
+
+				return httpmock.NewJsonResponse(http.StatusOK, M{})
+			},
+		)
+
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/update",
+			func(r *http.Request) (*http.Response, error) {
+				switch r.URL.RawQuery {
+				case "commit=true&openSearcher=false&waitSearcher=false",
+					"maxSegments=2&optimize=true",
+					"optimize=true":
+				default:
+					return nil, fmt.Errorf("unexpected params: %s", r.URL.RawQuery)
+				}
+
+				return httpmock.NewJsonResponse(http.StatusOK, M{})
+			},
+		)
+
+		_, err := client.DeleteByIDs(ctx, collection, []string{"1", "2"},
+			NewCommitOptions().CommitWithin(time.Second))
+		assert.NoError(t, err)
+
+		_, err = client.DeleteByIDs(ctx, collection, nil)
+		assert.Error(t, err)
+
+		query := NewStandardQueryParser().Query("product").Df("name")
+		_, err = client.DeleteByQuery(ctx, collection, query, NewCommitOptions().SoftCommit(true))
+		assert.NoError(t, err)
+
+		_, err = client.DeleteByQuery(ctx, collection, RawQuery("*:*"))
+		assert.NoError(t, err)
+
+		err = client.Commit(ctx, collection, NewCommitOptions().
+			WaitSearcher(false).OpenSearcher(false).CommitWithin(time.Second))
+		assert.NoError(t, err)
+
+		err = client.Optimize(ctx, collection, 2)
+		assert.NoError(t, err)
+
+		err = client.Optimize(ctx, collection, 0)
+		assert.NoError(t, err)
+
+		err = client.Rollback(ctx, collection)
+		assert.NoError(t, err)
+
+		_, err = clientThatErrors.DeleteByIDs(ctx, collection, []string{"1"})
+		assert.ErrorIs(t, err, errSendRequest)
+
+		_, err = clientThatErrors.DeleteByQuery(ctx, collection, RawQuery("*:*"))
+		assert.ErrorIs(t, err, errSendRequest)
+
+		err = clientThatErrors.Optimize(ctx, collection, 1)
+		assert.ErrorIs(t, err, errSendRequest)
+
+		err = clientThatErrors.Rollback(ctx, collection)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("add documents", func(t *testing.T) {
+		type product struct {
+			ID   string `solr:"id"`
+			Name string `solr:"name,omitempty"`
+		}
+
+		mockBody := `[{"id":"1","name":"product 1"},{"id":"2"},{"id":"3","name":"product 3"},{"id":"4","_version_":1,"stock_i":{"inc":-1}}]`
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/update",
+			newResponder(mockBody, M{}),
+		)
+
+		_, err := client.AddDocuments(ctx, collection,
+			product{ID: "1", Name: "product 1"},
+			&product{ID: "2"},
+			M{"id": "3", "name": "product 3"},
+			NewAtomicUpdate("4").Inc("stock_i", -1).Version(1),
+		)
+		assert.NoError(t, err)
+
+		_, err = client.AddDocuments(ctx, collection, "not a document")
+		assert.Error(t, err)
+
+		_, err = clientThatErrors.AddDocuments(ctx, collection, product{ID: "1"})
+		assert.ErrorIs(t, err, errSendRequest)
+
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/conflict/update",
+			httpmock.NewStringResponder(http.StatusConflict, `{"responseHeader":{"status":409},"error":{"msg":"version conflict for 4 expected=1 actual=2","code":409}}`),
+		)
+
+		_, err = client.AddDocuments(ctx, "conflict", NewAtomicUpdate("4").Set("name", "product 4").Version(1))
+		assert.True(t, IsConflict(err))
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
 	t.Run("schema", func(t *testing.T) {
 		t.Run("add fields", func(t *testing.T) {
 			mockBody := `{"add-field":[{"name":"foo","type":"string"},{"name":"bar","type":"string"}]}`
@@ -297,7 +493,7 @@ func TestJSONClientMock(t *testing.T) {
 		})
 
 		t.Run("add dynamic fields", func(t *testing.T) {
-			mockBody := `{"add-dynamic-field":[{"name":"*_foo","type":"string","stored":true},{"name":"*_bar","type":"plong"}]}`
+			mockBody := `{"add-dynamic-field":[{"name":"*_foo","type":"string","stored":true},{"name":"*_bar","type":"plong","stored":false}]}`
 			httpmock.RegisterResponder(
 				http.MethodPost,
 				baseURL+"/solr/"+collection+"/schema",
@@ -308,12 +504,12 @@ func TestJSONClientMock(t *testing.T) {
 				{
 					Name:   "*_foo",
 					Type:   "string",
-					Stored: true,
+					Stored: Bool(true),
 				},
 				{
 					Name:   "*_bar",
 					Type:   "plong",
-					Stored: false,
+					Stored: Bool(false),
 				},
 			}
 			err := client.AddDynamicFields(ctx, collection, fields...)
@@ -378,8 +574,8 @@ func TestJSONClientMock(t *testing.T) {
 					Class: "solr.TextField",
 					IndexAnalyzer: &Analyzer{
 						Tokenizer: &Tokenizer{
-							Class:     "solr.PathHierarchyTokenizerFactory",
-							Delimeter: "/",
+							Class:  "solr.PathHierarchyTokenizerFactory",
+							Params: []AnalyzerParam{{Name: "delimiter", Value: "/"}},
 						},
 					},
 					QueryAnalyzer: &Analyzer{
@@ -474,6 +670,193 @@ func TestJSONClientMock(t *testing.T) {
 			require.NoError(t, err)
 		})
 
+		t.Run("modify schema", func(t *testing.T) {
+			mockBody := `{"add-field-type":{"name":"text_new","class":"solr.TextField"},"add-field":{"name":"foo","type":"text_new"},"delete-copy-field":{"source":"bar","dest":"_text_"}}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/schema",
+				newResponder(mockBody, M{}),
+			)
+
+			commands := []SchemaCommand{
+				{Name: "add-field-type", Body: FieldType{Name: "text_new", Class: "solr.TextField"}},
+				{Name: "add-field", Body: Field{Name: "foo", Type: "text_new"}},
+				{Name: "delete-copy-field", Body: CopyField{Source: "bar", Dest: "_text_"}},
+			}
+			err := client.ModifySchema(ctx, collection, commands...)
+			require.NoError(t, err)
+
+			err = client.ModifySchema(ctx, collection)
+			assert.Error(t, err)
+
+			err = client.ModifySchema(ctx, collection, SchemaCommand{Name: "add-field", Body: make(chan int)})
+			assert.Error(t, err)
+
+			err = clientThatErrors.ModifySchema(ctx, collection, commands...)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("get schema", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema",
+				httpmock.NewStringResponder(http.StatusOK, `{
+					"responseHeader":{"status":0,"QTime":1},
+					"schema":{
+						"name":"default-config",
+						"version":1.6,
+						"uniqueKey":"id",
+						"fieldTypes":[{"name":"text_prefix","class":"solr.TextField","positionIncrementGap":"100","indexAnalyzer":{"tokenizer":{"class":"solr.StandardTokenizerFactory"},"filters":[{"class":"solr.EdgeNGramFilterFactory","minGramSize":"2","maxGramSize":"15"}]}}],
+						"fields":[{"name":"id","type":"string","multiValued":false,"indexed":true,"required":true,"stored":true}],
+						"dynamicFields":[{"name":"*_s","type":"string","indexed":true,"stored":true}],
+						"copyFields":[{"source":"name","dest":"_text_"}],
+						"similarity":{"class":"org.apache.solr.search.similarities.SchemaSimilarityFactory"}
+					}
+				}`),
+			)
+
+			schema, err := client.GetSchema(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, "default-config", schema.Name)
+			assert.Equal(t, 1.6, schema.Version)
+			assert.Equal(t, "id", schema.UniqueKey)
+			assert.Equal(t, []Field{{Name: "id", Type: "string", MultiValued: Bool(false), Indexed: Bool(true), Required: Bool(true), Stored: Bool(true)}}, schema.Fields)
+			assert.Equal(t, []Field{{Name: "*_s", Type: "string", Indexed: Bool(true), Stored: Bool(true)}}, schema.DynamicFields)
+			assert.Equal(t, []CopyField{{Source: "name", Dest: "_text_"}}, schema.CopyFields)
+			assert.Equal(t, M{"class": "org.apache.solr.search.similarities.SchemaSimilarityFactory"}, schema.Similarity)
+			require.Len(t, schema.FieldTypes, 1)
+			assert.Equal(t, NewAnalyzerComponent("solr.EdgeNGramFilterFactory").With("minGramSize", "2").With("maxGramSize", "15"),
+				schema.FieldTypes[0].IndexAnalyzer.Filters[0])
+
+			_, err = clientThatErrors.GetSchema(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("get schema xml", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema?wt=schema.xml",
+				httpmock.NewStringResponder(http.StatusOK, `<schema name="default-config" version="1.6"></schema>`),
+			)
+
+			b, err := client.GetSchemaXML(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, `<schema name="default-config" version="1.6"></schema>`, string(b))
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/notfound/schema?wt=schema.xml",
+				httpmock.NewStringResponder(http.StatusNotFound, `<html><body>Not Found</body></html>`),
+			)
+
+			_, err = client.GetSchemaXML(ctx, "notfound")
+			assert.True(t, IsNotFound(err))
+
+			_, err = clientThatErrors.GetSchemaXML(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("get fields", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/fields?showDefaults=true",
+				httpmock.NewStringResponder(http.StatusOK, `{"fields":[{"name":"id","type":"string","docValues":true,"indexed":true,"stored":true}]}`),
+			)
+
+			fields, err := client.GetFields(ctx, collection, NewSchemaOptions().ShowDefaults())
+			require.NoError(t, err)
+			assert.Equal(t, []Field{{Name: "id", Type: "string", DocValues: Bool(true), Indexed: Bool(true), Stored: Bool(true)}}, fields)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/fields/name_s?includeDynamic=true",
+				httpmock.NewStringResponder(http.StatusOK, `{"field":{"name":"*_s","type":"string","indexed":true,"stored":true}}`),
+			)
+
+			field, err := client.GetField(ctx, collection, "name_s", true)
+			require.NoError(t, err)
+			assert.Equal(t, &Field{Name: "*_s", Type: "string", Indexed: Bool(true), Stored: Bool(true)}, field)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/fields/missing",
+				httpmock.NewStringResponder(http.StatusNotFound, `{"responseHeader":{"status":404,"QTime":0},"error":{"msg":"No such path /schema/fields/missing","code":404}}`),
+			)
+
+			_, err = client.GetField(ctx, collection, "missing", false)
+			assert.True(t, IsNotFound(err))
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/dynamicfields",
+				httpmock.NewStringResponder(http.StatusOK, `{"dynamicFields":[{"name":"*_i","type":"pint"}]}`),
+			)
+
+			dynamicFields, err := client.GetDynamicFields(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, []Field{{Name: "*_i", Type: "pint"}}, dynamicFields)
+
+			_, err = clientThatErrors.GetFields(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("get field types and copy fields", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/fieldtypes",
+				httpmock.NewStringResponder(http.StatusOK, `{"fieldTypes":[{"name":"string","class":"solr.StrField","sortMissingLast":true}]}`),
+			)
+
+			fieldTypes, err := client.GetFieldTypes(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, []FieldType{{Name: "string", Class: "solr.StrField", SortMissingLast: Bool(true)}}, fieldTypes)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/copyfields",
+				httpmock.NewStringResponder(http.StatusOK, `{"copyFields":[{"source":"*","dest":"_text_","maxChars":256}]}`),
+			)
+
+			copyFields, err := client.GetCopyFields(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, []CopyField{{Source: "*", Dest: "_text_", MaxChars: 256}}, copyFields)
+		})
+
+		t.Run("get unique key, similarity and version", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/uniquekey",
+				httpmock.NewStringResponder(http.StatusOK, `{"uniqueKey":"id"}`),
+			)
+
+			uniqueKey, err := client.GetUniqueKey(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, "id", uniqueKey)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/similarity",
+				httpmock.NewStringResponder(http.StatusOK, `{"similarity":{"class":"org.apache.solr.search.similarities.BM25SimilarityFactory","k1":"1.2"}}`),
+			)
+
+			similarity, err := client.GetSimilarity(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, M{"class": "org.apache.solr.search.similarities.BM25SimilarityFactory", "k1": "1.2"}, similarity)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/version",
+				httpmock.NewStringResponder(http.StatusOK, `{"version":1.6}`),
+			)
+
+			version, err := client.GetSchemaVersion(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, 1.6, version)
+
+			_, err = clientThatErrors.GetUniqueKey(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
 		t.Run("error", func(t *testing.T) {
 			mockBody := `{"add-field":[{"name":"foo"}]}`
 			httpmock.RegisterResponder(
@@ -522,53 +905,640 @@ func TestJSONClientMock(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
-		t.Run("add components", func(t *testing.T) {
-			mockBody := `{"add-searchcomponent":{"class":"solr.SuggestComponent","name":"suggest","suggester":{"dictionaryImpl":"DocumentDictionaryFactory","field":"suggest","lookupImpl":"AnalyzingInfixLookupFactory","name":"default","suggestAnalyzerFieldType":"suggest_text"}},"add-requesthandler":{"class":"solr.SearchHandler","components":["suggest"],"defaults":{"suggest":true,"suggest.count":10,"suggest.dictionary":"default"},"name":"/suggest","startup":"lazy"}}`
+		t.Run("user properties", func(t *testing.T) {
+			mockBody := `{"set-user-property":{"my.autoCommit.maxTime":"15000","my.rows":"10"}}`
 			httpmock.RegisterResponder(
 				http.MethodPost,
 				baseURL+"/solr/"+collection+"/config",
 				newResponder(mockBody, M{}),
 			)
 
-			suggestComponent := NewComponent(SearchComponent).
-				Name("suggest").Class("solr.SuggestComponent").
-				Config(M{
-					"suggester": M{
-						"name":                     "default",
-						"lookupImpl":               "AnalyzingInfixLookupFactory",
-						"dictionaryImpl":           "DocumentDictionaryFactory",
-						"field":                    "suggest",
-						"suggestAnalyzerFieldType": "suggest_text",
-					},
-				})
+			err := client.SetUserProperties(ctx, collection, M{
+				"my.autoCommit.maxTime": "15000",
+				"my.rows":               "10",
+			})
+			assert.NoError(t, err)
 
-			suggestHandler := NewComponent(RequestHandler).
-				Name("/suggest").Class("solr.SearchHandler").
-				Config(M{
-					"startup": "lazy",
-					"defaults": M{
-						"suggest":            true,
-						"suggest.count":      10,
-						"suggest.dictionary": "default",
-					},
-					"components": []string{"suggest"},
-				})
+			mockBody = `{"unset-user-property":"my.rows"}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config",
+				newResponder(mockBody, M{}),
+			)
 
-			err := client.AddComponents(ctx, collection, suggestComponent, suggestHandler)
+			err = client.UnsetUserProperty(ctx, collection, "my.rows")
 			assert.NoError(t, err)
 
-			err = clientThatErrors.AddComponents(ctx, collection, suggestComponent)
-			assert.ErrorIs(t, err, errSendRequest)
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/overlay",
+				httpmock.NewStringResponder(http.StatusOK, `{"overlay":{"znodeVersion":1,"userProps":{"my.autoCommit.maxTime":"15000"}}}`),
+			)
+
+			props, err := client.GetUserProperties(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, M{"my.autoCommit.maxTime": "15000"}, props)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/overlay",
+				httpmock.NewStringResponder(http.StatusOK, `{"overlay":{"znodeVersion":0}}`),
+			)
+
+			props, err = client.GetUserProperties(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, M{}, props)
+
+			_, err = clientThatErrors.GetUserProperties(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("get config", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config",
+				httpmock.NewStringResponder(http.StatusOK, `{
+					"responseHeader":{"status":0,"QTime":1},
+					"config":{
+						"luceneMatchVersion":"8.8.0",
+						"updateHandler":{
+							"class":"solr.DirectUpdateHandler2",
+							"indexWriter":{"closeWaitsForMerges":true},
+							"commitWithin":{"softCommit":true},
+							"autoCommit":{"maxDocs":-1,"maxTime":15000,"openSearcher":false},
+							"autoSoftCommit":{"maxDocs":-1,"maxTime":-1}
+						},
+						"query":{
+							"useFilterForSortedQuery":false,
+							"queryResultWindowSize":20,
+							"queryResultMaxDocsCached":200,
+							"enableLazyFieldLoading":true,
+							"maxBooleanClauses":1024,
+							"filterCache":{"autowarmCount":"0","size":"512","initialSize":"512","class":"solr.FastLRUCache","name":"filterCache"},
+							"documentCache":{"autowarmCount":"10%","size":512,"class":"solr.CaffeineCache","name":"documentCache"}
+						},
+						"requestHandler":{
+							"/select":{"name":"/select","class":"solr.SearchHandler","defaults":{"echoParams":"explicit","rows":10}}
+						},
+						"searchComponent":{
+							"spellcheck":{"name":"spellcheck","class":"solr.SpellCheckComponent"}
+						}
+					}
+				}`),
+			)
+
+			resp, err := client.GetConfig(ctx, collection, "")
+			require.NoError(t, err)
+			require.NotNil(t, resp.Config)
+
+			config := resp.Config
+			assert.Equal(t, "8.8.0", config.LuceneMatchVersion)
+
+			require.NotNil(t, config.UpdateHandler)
+			assert.Equal(t, "solr.DirectUpdateHandler2", config.UpdateHandler.Class)
+			assert.Equal(t, &AutoCommitConfig{MaxDocs: -1, MaxTime: 15000}, config.UpdateHandler.AutoCommit)
+			assert.Equal(t, &AutoCommitConfig{MaxDocs: -1, MaxTime: -1}, config.UpdateHandler.AutoSoftCommit)
+			assert.True(t, config.UpdateHandler.IndexWriter.CloseWaitsForMerges)
+			assert.True(t, config.UpdateHandler.CommitWithin.SoftCommit)
+
+			require.NotNil(t, config.Query)
+			assert.Equal(t, 1024, config.Query.MaxBooleanClauses)
+			assert.Equal(t, &CacheConfig{
+				Name:          "filterCache",
+				Class:         "solr.FastLRUCache",
+				Size:          512,
+				InitialSize:   512,
+				AutowarmCount: "0",
+			}, config.Query.FilterCache)
+			assert.Equal(t, &CacheConfig{
+				Name:          "documentCache",
+				Class:         "solr.CaffeineCache",
+				Size:          512,
+				AutowarmCount: "10%",
+			}, config.Query.DocumentCache)
+			assert.Nil(t, config.Query.QueryResultCache)
+
+			assert.Equal(t, &RequestHandlerConfig{
+				Name:     "/select",
+				Class:    "solr.SearchHandler",
+				Defaults: M{"echoParams": "explicit", "rows": float64(10)},
+			}, config.RequestHandlers["/select"])
+			assert.Contains(t, config.Raw, "searchComponent")
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/requestHandler",
+				httpmock.NewStringResponder(http.StatusOK, `{"config":{"requestHandler":{"/update":{"name":"/update","class":"solr.UpdateRequestHandler"}}}}`),
+			)
+
+			resp, err = client.GetConfig(ctx, collection, "requestHandler")
+			require.NoError(t, err)
+			assert.Nil(t, resp.Config.UpdateHandler)
+			assert.Equal(t, "solr.UpdateRequestHandler", resp.Config.RequestHandlers["/update"].Class)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/query",
+				httpmock.NewStringResponder(http.StatusOK, `{"config":{"query":{"filterCache":{"size":"big"}}}}`),
+			)
+
+			_, err = client.GetConfig(ctx, collection, "query")
+			assert.Error(t, err)
+
+			_, err = clientThatErrors.GetConfig(ctx, collection, "")
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("get config overlay", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/overlay",
+				httpmock.NewStringResponder(http.StatusOK, `{
+					"responseHeader":{"status":0,"QTime":0},
+					"overlay":{
+						"znodeVersion":3,
+						"props":{"updateHandler":{"autoCommit":{"maxTime":15000}}},
+						"userProps":{"update.autocreate":"true"},
+						"requestHandler":{"/mypath":{"name":"/mypath","class":"solr.DumpRequestHandler","defaults":{"x":"y"}}},
+						"searchComponent":{"elevator":{"name":"elevator","class":"solr.QueryElevationComponent"}}
+					}
+				}`),
+			)
+
+			resp, err := client.GetConfigOverlay(ctx, collection)
+			require.NoError(t, err)
+
+			overlay := resp.Overlay
+			require.NotNil(t, overlay)
+			assert.Equal(t, 3, overlay.ZNodeVersion)
+			assert.Equal(t, M{"update.autocreate": "true"}, overlay.UserProps)
+			assert.Equal(t, "solr.DumpRequestHandler", overlay.RequestHandlers["/mypath"].Class)
+			assert.Contains(t, overlay.Raw, "searchComponent")
+
+			maxTime, ok := overlay.Property("updateHandler.autoCommit.maxTime")
+			assert.True(t, ok)
+			assert.Equal(t, float64(15000), maxTime)
+
+			_, ok = overlay.Property("updateHandler.autoSoftCommit.maxTime")
+			assert.False(t, ok)
+
+			_, ok = overlay.Property("updateHandler.autoCommit.maxTime.x")
+			assert.False(t, ok)
+
+			_, err = clientThatErrors.GetConfigOverlay(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("set and update params", func(t *testing.T) {
+			mockBody := `{"set":{"myQueries":{"defType":"edismax","rows":"5","_invariants_":{"wt":"json"}}}}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config/params",
+				newResponder(mockBody, M{}),
+			)
+
+			err := client.SetParams(ctx, collection, ParamSet{
+				Name:       "myQueries",
+				Params:     M{"defType": "edismax", "rows": "5"},
+				Invariants: M{"wt": "json"},
+			})
+			assert.NoError(t, err)
+
+			mockBody = `{"update":{"myQueries":{"rows":"10"},"myFacets":{"facet":"true"}}}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config/params",
+				newResponder(mockBody, M{}),
+			)
+
+			err = client.UpdateParams(ctx, collection,
+				ParamSet{Name: "myQueries", Params: M{"rows": "10"}},
+				ParamSet{Name: "myFacets", Params: M{"facet": "true"}},
+			)
+			assert.NoError(t, err)
+
+			err = client.SetParams(ctx, collection)
+			assert.Error(t, err)
+		})
+
+		t.Run("delete params", func(t *testing.T) {
+			mockBody := `{"delete":["myQueries","myFacets"]}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config/params",
+				newResponder(mockBody, M{}),
+			)
+
+			err := client.DeleteParams(ctx, collection, "myQueries", "myFacets")
+			assert.NoError(t, err)
+
+			err = client.DeleteParams(ctx, collection)
+			assert.Error(t, err)
+		})
+
+		t.Run("get params", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/params",
+				httpmock.NewStringResponder(http.StatusOK, `{
+					"responseHeader":{"status":0,"QTime":0},
+					"response":{
+						"znodeVersion":2,
+						"params":{
+							"myQueries":{"defType":"edismax","rows":"5","_appends_":{"fq":"inStock:true"},"":{"v":1}},
+							"myFacets":{"facet":"true","":{"v":0}},
+							"other":{"q":"*:*","":{"v":0}}
+						}
+					}
+				}`),
+			)
+
+			paramSets, err := client.GetParams(ctx, collection)
+			require.NoError(t, err)
+			require.Len(t, paramSets, 3)
+			assert.Equal(t, "myFacets", paramSets[0].Name)
+			assert.Equal(t, ParamSet{
+				Name:    "myQueries",
+				Params:  M{"defType": "edismax", "rows": "5"},
+				Appends: M{"fq": "inStock:true"},
+				Version: 1,
+			}, paramSets[1])
+
+			paramSets, err = client.GetParams(ctx, collection, "other", "myFacets")
+			require.NoError(t, err)
+			require.Len(t, paramSets, 2)
+			assert.Equal(t, "myFacets", paramSets[0].Name)
+			assert.Equal(t, "other", paramSets[1].Name)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/config/params/myFacets",
+				httpmock.NewStringResponder(http.StatusOK, `{"response":{"znodeVersion":2,"params":{"myFacets":{"facet":"true","":{"v":0}}}}}`),
+			)
+
+			paramSets, err = client.GetParams(ctx, collection, "myFacets")
+			require.NoError(t, err)
+			assert.Equal(t, []ParamSet{{Name: "myFacets", Params: M{"facet": "true"}}}, paramSets)
+
+			_, err = clientThatErrors.GetParams(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("add components", func(t *testing.T) {
+			mockBody := `{"add-searchcomponent":{"class":"solr.SuggestComponent","name":"suggest","suggester":{"dictionaryImpl":"DocumentDictionaryFactory","field":"suggest","lookupImpl":"AnalyzingInfixLookupFactory","name":"default","suggestAnalyzerFieldType":"suggest_text"}},"add-requesthandler":{"class":"solr.SearchHandler","components":["suggest"],"defaults":{"suggest":true,"suggest.count":10,"suggest.dictionary":"default"},"name":"/suggest","startup":"lazy"}}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config",
+				newResponder(mockBody, M{}),
+			)
+
+			suggestComponent := NewComponent(SearchComponent).
+				Name("suggest").Class("solr.SuggestComponent").
+				Config(M{
+					"suggester": M{
+						"name":                     "default",
+						"lookupImpl":               "AnalyzingInfixLookupFactory",
+						"dictionaryImpl":           "DocumentDictionaryFactory",
+						"field":                    "suggest",
+						"suggestAnalyzerFieldType": "suggest_text",
+					},
+				})
+
+			suggestHandler := NewComponent(RequestHandler).
+				Name("/suggest").Class("solr.SearchHandler").
+				Config(M{
+					"startup": "lazy",
+					"defaults": M{
+						"suggest":            true,
+						"suggest.count":      10,
+						"suggest.dictionary": "default",
+					},
+					"components": []string{"suggest"},
+				})
+
+			err := client.AddComponents(ctx, collection, suggestComponent, suggestHandler)
+			assert.NoError(t, err)
+
+			err = clientThatErrors.AddComponents(ctx, collection, suggestComponent)
+			assert.ErrorIs(t, err, errSendRequest)
 		})
 
 		t.Run("update components", func(t *testing.T) {
-			err := client.UpdateComponents(ctx, collection)
+			mockBody := `{"update-searchcomponent":{"class":"solr.SuggestComponent","name":"suggest"},"update-initparams":{"defaults":{"df":"name"},"name":"myparams","path":"/select"}}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config",
+				newResponder(mockBody, M{}),
+			)
+
+			err := client.UpdateComponents(ctx, collection,
+				NewComponent(SearchComponent).Name("suggest").Class("solr.SuggestComponent"),
+				NewComponent(InitParams).Name("myparams").Config(M{
+					"path":     "/select",
+					"defaults": M{"df": "name"},
+				}),
+			)
+			assert.NoError(t, err)
+
+			err = client.UpdateComponents(ctx, collection)
 			assert.Error(t, err)
+
+			err = clientThatErrors.UpdateComponents(ctx, collection, NewComponent(SearchComponent).Name("suggest"))
+			assert.ErrorIs(t, err, errSendRequest)
 		})
 
 		t.Run("delete components", func(t *testing.T) {
-			err := client.DeleteComponents(ctx, collection)
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config",
+				func(r *http.Request) (*http.Response, error) {
+					b, err := io.ReadAll(r.Body)
+					if err != nil {
+						return nil, err
+					}
+
+					// the same command can appear multiple times
+					expect := `{"delete-requesthandler":"/suggest","delete-requesthandler":"/mlt","delete-queryresponsewriter":"myWriter"}`
+					if string(b) != expect {
+						return nil, fmt.Errorf("unexpected request body: %s", string(b))
+					}
+
+					return httpmock.NewJsonResponse(http.StatusOK, M{})
+				},
+			)
+
+			err := client.DeleteComponents(ctx, collection,
+				NewComponent(RequestHandler).Name("/suggest"),
+				NewComponent(RequestHandler).Name("/mlt"),
+				NewComponent(QueryResponseWriter).Name("myWriter"),
+			)
+			assert.NoError(t, err)
+
+			err = client.DeleteComponents(ctx, collection)
 			assert.Error(t, err)
+
+			err = clientThatErrors.DeleteComponents(ctx, collection, NewComponent(RequestHandler).Name("/suggest"))
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("command errors", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config",
+				httpmock.NewStringResponder(http.StatusBadRequest, `{
+					"responseHeader": {"status": 400, "QTime": 1},
+					"errorMessages": [{
+						"delete-requesthandler": "/unknown",
+						"errorMessages": ["NO such requestHandler '/unknown' "]
+					}],
+					"error": {
+						"metadata": ["error-class", "org.apache.solr.api.ApiBag$ExceptionWithErrObject"],
+						"details": [{
+							"delete-requesthandler": "/unknown",
+							"errorMessages": ["NO such requestHandler '/unknown' "]
+						}],
+						"msg": "error processing commands",
+						"code": 400
+					}
+				}`),
+			)
+
+			err := client.DeleteComponents(ctx, collection, NewComponent(RequestHandler).Name("/unknown"))
+			require.Error(t, err)
+			assert.True(t, IsBadRequest(err))
+
+			var solrErr *SolrError
+			require.ErrorAs(t, err, &solrErr)
+			require.Len(t, solrErr.Err.Details, 1)
+			assert.Equal(t, "delete-requesthandler", solrErr.Err.Details[0].Command)
+			assert.Equal(t, "/unknown", solrErr.Err.Details[0].Body)
+			assert.Equal(t, []string{"NO such requestHandler '/unknown' "}, solrErr.Err.Details[0].Messages)
+			assert.Contains(t, err.Error(), "delete-requesthandler: NO such requestHandler")
+
+			// older versions only have the top-level error messages
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/config",
+				httpmock.NewStringResponder(http.StatusBadRequest, `{
+					"errorMessages": [{"update-searchcomponent": {"name": "x"}, "errorMessages": ["'class' is a required field"]}],
+					"error": {"msg": "error processing commands", "code": 400}
+				}`),
+			)
+
+			err = client.UpdateComponents(ctx, collection, NewComponent(SearchComponent).Name("x"))
+			require.ErrorAs(t, err, &solrErr)
+			require.Len(t, solrErr.Err.Details, 1)
+			assert.Equal(t, "update-searchcomponent", solrErr.Err.Details[0].Command)
+			assert.Equal(t, map[string]interface{}{"name": "x"}, solrErr.Err.Details[0].Body)
+		})
+	})
+
+	t.Run("managed resources", func(t *testing.T) {
+		managedURL := baseURL + "/solr/" + collection + "/schema/analysis"
+
+		t.Run("list managed resources", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/"+collection+"/schema/managed",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1},"managedResources":[{"resourceId":"/schema/analysis/stopwords/english","class":"org.apache.solr.rest.schema.analysis.ManagedWordSetResource","numObservers":"1"}]}`),
+			)
+
+			resources, err := client.ListManagedResources(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, []ManagedResource{{
+				ResourceID:   "/schema/analysis/stopwords/english",
+				Class:        "org.apache.solr.rest.schema.analysis.ManagedWordSetResource",
+				NumObservers: 1,
+			}}, resources)
+
+			_, err = clientThatErrors.ListManagedResources(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("stopwords", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				managedURL+"/stopwords/english",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1},"wordSet":{"initArgs":{"ignoreCase":true},"initializedOn":"2014-03-28T20:53:53.058Z","managedList":["a","an","and"]}}`),
+			)
+
+			wordSet, err := client.GetStopwords(ctx, collection, "english")
+			require.NoError(t, err)
+			assert.Equal(t, &ManagedWordSet{
+				InitArgs:      ManagedInitArgs{IgnoreCase: true},
+				InitializedOn: "2014-03-28T20:53:53.058Z",
+				ManagedList:   []string{"a", "an", "and"},
+			}, wordSet)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				managedURL+"/stopwords/english/and",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1},"and":"and"}`),
+			)
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				managedURL+"/stopwords/english/foo",
+				httpmock.NewStringResponder(http.StatusNotFound, `{"responseHeader":{"status":404,"QTime":1},"error":{"msg":"foo not found in /schema/analysis/stopwords/english","code":404}}`),
+			)
+
+			ok, err := client.HasStopword(ctx, collection, "english", "and")
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = client.HasStopword(ctx, collection, "english", "foo")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			httpmock.RegisterResponder(
+				http.MethodPut,
+				managedURL+"/stopwords/english",
+				newResponder(`["foo","bar"]`, M{}),
+			)
+
+			err = client.AddStopwords(ctx, collection, "english", "foo", "bar")
+			assert.NoError(t, err)
+
+			err = client.AddStopwords(ctx, collection, "english")
+			assert.EqualError(t, err, "no stopwords")
+
+			httpmock.RegisterResponder(
+				http.MethodPut,
+				managedURL+"/stopwords/english",
+				newResponder(`{"initArgs":{"ignoreCase":false}}`, M{}),
+			)
+
+			err = client.SetStopwordsIgnoreCase(ctx, collection, "english", false)
+			assert.NoError(t, err)
+
+			httpmock.RegisterResponder(
+				http.MethodDelete,
+				managedURL+"/stopwords/english/foo",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1}}`),
+			)
+
+			err = client.DeleteStopword(ctx, collection, "english", "foo")
+			assert.NoError(t, err)
+
+			_, err = clientThatErrors.HasStopword(ctx, collection, "english", "foo")
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("synonyms", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				managedURL+"/synonyms/english",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1},"synonymMappings":{"initArgs":{"ignoreCase":true,"format":"solr"},"initializedOn":"2014-12-16T22:44:05.33Z","managedMap":{"GB":["GiB","Gigabyte"],"TV":["Television"],"happy":["glad","joyful"]}}}`),
+			)
+
+			synonyms, err := client.GetSynonyms(ctx, collection, "english")
+			require.NoError(t, err)
+			assert.Equal(t, &ManagedSynonymMap{
+				InitArgs:      ManagedInitArgs{IgnoreCase: true, Format: "solr"},
+				InitializedOn: "2014-12-16T22:44:05.33Z",
+				ManagedMap: map[string][]string{
+					"GB":    {"GiB", "Gigabyte"},
+					"TV":    {"Television"},
+					"happy": {"glad", "joyful"},
+				},
+			}, synonyms)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				managedURL+"/synonyms/english/mad",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1},"mad":["angry","upset"]}`),
+			)
+
+			mad, err := client.GetSynonym(ctx, collection, "english", "mad")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"angry", "upset"}, mad)
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				managedURL+"/synonyms/english/Mad",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1},"mad":["angry","upset"]}`),
+			)
+
+			mad, err = client.GetSynonym(ctx, collection, "english", "Mad")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"angry", "upset"}, mad)
+
+			httpmock.RegisterResponder(
+				http.MethodPut,
+				managedURL+"/synonyms/english",
+				newResponder(`{"mad":["angry","upset"]}`, M{}),
+			)
+
+			err = client.AddSynonyms(ctx, collection, "english", map[string][]string{
+				"mad": {"angry", "upset"},
+			})
+			assert.NoError(t, err)
+
+			err = client.AddSynonyms(ctx, collection, "english", nil)
+			assert.EqualError(t, err, "no synonyms")
+
+			httpmock.RegisterResponder(
+				http.MethodPut,
+				managedURL+"/synonyms/english",
+				newResponder(`{"initArgs":{"ignoreCase":false}}`, M{}),
+			)
+
+			err = client.SetSynonymsIgnoreCase(ctx, collection, "english", false)
+			assert.NoError(t, err)
+
+			httpmock.RegisterResponder(
+				http.MethodDelete,
+				managedURL+"/synonyms/english/mad",
+				httpmock.NewStringResponder(http.StatusOK, `{"responseHeader":{"status":0,"QTime":1}}`),
+			)
+
+			err = client.DeleteSynonym(ctx, collection, "english", "mad")
+			assert.NoError(t, err)
+
+			_, err = clientThatErrors.GetSynonym(ctx, collection, "english", "mad")
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("replace synonyms", func(t *testing.T) {
+			deleted := []string{}
+			for _, term := range []string{"GB", "TV", "happy"} {
+				term := term
+				httpmock.RegisterResponder(
+					http.MethodDelete,
+					managedURL+"/synonyms/english/"+term,
+					func(r *http.Request) (*http.Response, error) {
+						deleted = append(deleted, term)
+						return httpmock.NewJsonResponse(http.StatusOK, M{})
+					},
+				)
+			}
+
+			// GB is unchanged, TV is removed and happy is changed
+			httpmock.RegisterResponder(
+				http.MethodPut,
+				managedURL+"/synonyms/english",
+				newResponder(`{"happy":["glad"],"mad":["angry","upset"]}`, M{}),
+			)
+
+			err := client.ReplaceSynonyms(ctx, collection, "english", map[string][]string{
+				"GB":    {"Gigabyte", "GiB"},
+				"happy": {"glad"},
+				"mad":   {"angry", "upset"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"TV", "happy"}, deleted)
+
+			// nothing changed
+			deleted = []string{}
+			err = client.ReplaceSynonyms(ctx, collection, "english", map[string][]string{
+				"GB":    {"GiB", "Gigabyte"},
+				"TV":    {"Television"},
+				"happy": {"glad", "joyful"},
+			})
+			require.NoError(t, err)
+			assert.Empty(t, deleted)
+
+			err = clientThatErrors.ReplaceSynonyms(ctx, collection, "english", nil)
+			assert.ErrorIs(t, err, errSendRequest)
 		})
 	})
 
@@ -590,6 +1560,361 @@ func TestJSONClientMock(t *testing.T) {
 		assert.ErrorIs(t, err, errSendRequest)
 	})
 
+	t.Run("export", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/schema/fields?showDefaults=true",
+			httpmock.NewStringResponder(http.StatusOK, `{"fields":[
+				{"name":"id","type":"string","docValues":true},
+				{"name":"name","type":"text_general"},
+				{"name":"price_f","type":"pfloat","docValues":true}
+			]}`),
+		)
+
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/schema/dynamicfields?showDefaults=true",
+			httpmock.NewStringResponder(http.StatusOK, `{"dynamicFields":[
+				{"name":"*_s","type":"string","docValues":true},
+				{"name":"*_txt_s","type":"text_general"},
+				{"name":"attr_*","type":"string","docValues":true}
+			]}`),
+		)
+
+		exportURL := baseURL + "/solr/" + collection + "/export"
+		exports := func() int {
+			return httpmock.GetCallCountInfo()[http.MethodPost+" "+exportURL]
+		}
+		registerExport := func(body string) {
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				exportURL,
+				func(r *http.Request) (*http.Response, error) {
+					if sort := r.FormValue("sort"); sort != "id asc" {
+						return nil, fmt.Errorf("unexpected sort: %s", sort)
+					}
+
+					return httpmock.NewStringResponse(http.StatusOK, body), nil
+				},
+			)
+		}
+
+		t.Run("ok", func(t *testing.T) {
+			registerExport(`{"responseHeader":{"status":0},"response":{"numFound":2,"docs":[
+				{"id":"1","cat_s":"books"},
+				{"id":"2","attr_color":"red"}
+			]}}`)
+
+			calls := exports()
+			it, err := client.Export(ctx, collection, NewExportParams("*:*", "id asc", "id,cat_s", "attr_color"))
+			require.NoError(t, err)
+			defer it.Close()
+
+			var ids []interface{}
+			for it.Next() {
+				ids = append(ids, it.Tuple()["id"])
+			}
+			require.NoError(t, it.Err())
+
+			assert.Equal(t, []interface{}{"1", "2"}, ids)
+			assert.Equal(t, 2, it.NumFound())
+			assert.Equal(t, calls+1, exports())
+
+			_, err = clientThatErrors.Export(ctx, collection,
+				NewExportParams("*:*", "id asc", "id").SkipValidation())
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("long values", func(t *testing.T) {
+			registerExport(`{"response":{"numFound":1,"docs":[
+				{"id":"1","_version_":1712345678901234567}
+			]}}`)
+
+			it, err := client.Export(ctx, collection, NewExportParams("*:*", "id asc", "id,_version_").SkipValidation())
+			require.NoError(t, err)
+			defer it.Close()
+
+			require.True(t, it.Next())
+			b, err := json.Marshal(it.Tuple())
+			require.NoError(t, err)
+			assert.Equal(t, `{"_version_":1712345678901234567,"id":"1"}`, string(b))
+		})
+
+		t.Run("validation", func(t *testing.T) {
+			registerExport(`{"response":{"numFound":0,"docs":[]}}`)
+
+			calls := exports()
+			for _, params := range []*ExportParams{
+				NewExportParams("*:*", "id asc", "name"),
+				NewExportParams("*:*", "id asc", "title_txt_s"),
+				NewExportParams("*:*", "id asc", "unknown"),
+				NewExportParams("*:*", "name desc, id asc", "id"),
+			} {
+				_, err := client.Export(ctx, collection, params)
+				assert.Error(t, err)
+			}
+			assert.Equal(t, calls, exports())
+
+			it, err := client.Export(ctx, collection,
+				NewExportParams("*:*", "id asc", "name").SkipValidation())
+			require.NoError(t, err)
+			assert.False(t, it.Next())
+			assert.NoError(t, it.Err())
+			assert.Equal(t, calls+1, exports())
+		})
+
+		t.Run("exception", func(t *testing.T) {
+			registerExport(`{"responseHeader":{"status":400},"response":{"numFound":0,"docs":[
+				{"EXCEPTION":"Export fields must be specified by the fl parameter"}
+			]}}`)
+
+			it, err := client.Export(ctx, collection, NewExportParams("*:*", "id asc", "id"))
+			require.NoError(t, err)
+
+			assert.False(t, it.Next())
+			var streamErr *StreamError
+			assert.ErrorAs(t, it.Err(), &streamErr)
+		})
+
+		t.Run("schema error", func(t *testing.T) {
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				baseURL+"/solr/notfound/schema/fields?showDefaults=true",
+				httpmock.NewStringResponder(http.StatusNotFound, `{"error":{"msg":"Collection not found: notfound","code":404}}`),
+			)
+
+			_, err := client.Export(ctx, "notfound", NewExportParams("*:*", "id asc", "id"))
+			assert.True(t, IsNotFound(err))
+		})
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		expr := NewSearchStream(collection).Q("*:*")
+		streamURL := baseURL + "/solr/" + collection + "/stream"
+		registerStream := func(status int, body string) {
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				streamURL,
+				func(r *http.Request) (*http.Response, error) {
+					if contentType := r.Header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+						return nil, fmt.Errorf("unexpected content type: %s", contentType)
+					}
+
+					if got := r.FormValue("expr"); got != `search(products, q="*:*")` {
+						return nil, fmt.Errorf("unexpected expr: %s", got)
+					}
+
+					return httpmock.NewStringResponse(status, body), nil
+				},
+			)
+		}
+
+		t.Run("ok", func(t *testing.T) {
+			registerStream(http.StatusOK, `{"result-set":{"docs":[
+				{"id":"1","price_f":1.5},
+				{"id":"2","price_f":2.5},
+				{"EOF":true,"RESPONSE_TIME":33}
+			]}}`)
+
+			it, err := client.Stream(ctx, collection, expr)
+			require.NoError(t, err)
+			defer it.Close()
+
+			var ids []interface{}
+			for it.Next() {
+				ids = append(ids, it.Tuple()["id"])
+			}
+			require.NoError(t, it.Err())
+
+			assert.Equal(t, []interface{}{"1", "2"}, ids)
+			assert.Equal(t, 33*time.Millisecond, it.ResponseTime())
+			assert.False(t, it.Next())
+
+			_, err = clientThatErrors.Stream(ctx, collection, expr)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("exception", func(t *testing.T) {
+			registerStream(http.StatusOK, `{"result-set":{"docs":[
+				{"id":"1"},
+				{"EXCEPTION":"Invalid stream expression","EOF":true,"RESPONSE_TIME":1}
+			]}}`)
+
+			it, err := client.Stream(ctx, collection, expr)
+			require.NoError(t, err)
+
+			assert.True(t, it.Next())
+			assert.False(t, it.Next())
+
+			var streamErr *StreamError
+			require.ErrorAs(t, it.Err(), &streamErr)
+			assert.Equal(t, "Invalid stream expression", streamErr.Message)
+		})
+
+		t.Run("malformed responses", func(t *testing.T) {
+			bodies := []string{
+				`{"result-set":{"docs":[{"id":"1"}]}}`,
+				`{"result-set":{"numFound":1}}`,
+				`{"responseHeader":{"status":0}}`,
+				`[]`,
+				`{"result-set":{"docs":[{"id":`,
+			}
+
+			for _, body := range bodies {
+				registerStream(http.StatusOK, body)
+
+				it, err := client.Stream(ctx, collection, expr)
+				require.NoError(t, err)
+
+				for it.Next() {
+				}
+				assert.Error(t, it.Err(), body)
+			}
+		})
+
+		t.Run("solr error", func(t *testing.T) {
+			registerStream(http.StatusNotFound, `{"error":{"msg":"Collection not found","code":404}}`)
+
+			_, err := client.Stream(ctx, collection, expr)
+			assert.True(t, IsNotFound(err))
+		})
+
+		t.Run("tuples are decoded incrementally", func(t *testing.T) {
+			pr, pw := io.Pipe()
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				streamURL,
+				func(r *http.Request) (*http.Response, error) {
+					resp := httpmock.NewStringResponse(http.StatusOK, "")
+					resp.Body = pr
+					return resp, nil
+				},
+			)
+
+			go func() {
+				_, _ = pw.Write([]byte(`{"result-set":{"docs":[{"id":"1"},`))
+			}()
+
+			it, err := client.Stream(ctx, collection, expr)
+			require.NoError(t, err)
+
+			// the first tuple is available before the response is complete
+			require.True(t, it.Next())
+			assert.Equal(t, "1", it.Tuple()["id"])
+
+			go func() {
+				_, _ = pw.Write([]byte(`{"EOF":true}]}}`))
+				_ = pw.Close()
+			}()
+			assert.False(t, it.Next())
+			assert.NoError(t, it.Err())
+		})
+
+		t.Run("close before the end", func(t *testing.T) {
+			registerStream(http.StatusOK, `{"result-set":{"docs":[{"id":"1"},{"id":"2"},{"EOF":true}]}}`)
+
+			it, err := client.Stream(ctx, collection, expr)
+			require.NoError(t, err)
+
+			require.True(t, it.Next())
+			require.NoError(t, it.Close())
+			assert.False(t, it.Next())
+			assert.NoError(t, it.Err())
+		})
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/sql",
+			func(r *http.Request) (*http.Response, error) {
+				if stmt := r.FormValue("stmt"); stmt != "SELECT id FROM products" {
+					return nil, fmt.Errorf("unexpected stmt: %s", stmt)
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"result-set":{"docs":[{"id":"1","count":2,"price":1.0},{"EOF":true,"RESPONSE_TIME":12}]}}`), nil
+			},
+		)
+
+		params := NewSQLParams("SELECT id FROM products")
+		it, err := client.SQL(ctx, collection, params)
+		require.NoError(t, err)
+		defer it.Close()
+
+		require.True(t, it.Next())
+		assert.Equal(t, M{"id": "1", "count": json.Number("2"), "price": json.Number("1.0")}, it.Tuple())
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+		assert.Equal(t, 12*time.Millisecond, it.ResponseTime())
+
+		_, err = clientThatErrors.SQL(ctx, collection, params)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("solr error", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/notfound/query",
+			httpmock.NewStringResponder(http.StatusNotFound, `{"responseHeader":{"status":404,"QTime":0},"error":{"metadata":["error-class","org.apache.solr.common.SolrException","root-error-class","org.apache.solr.common.SolrException"],"msg":"Collection not found: notfound","code":404}}`),
+		)
+
+		_, err := client.Query(ctx, "notfound", NewQuery("*:*"))
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+
+		var solrErr *SolrError
+		require.True(t, errors.As(err, &solrErr))
+		assert.Equal(t, http.StatusNotFound, solrErr.StatusCode)
+		assert.Equal(t, baseURL+"/solr/notfound/query", solrErr.URL)
+		assert.Equal(t, "org.apache.solr.common.SolrException", solrErr.ErrorClass)
+		assert.Equal(t, "Collection not found: notfound", solrErr.Err.Msg)
+
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/unavailable/update",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, "<html><body>Service Unavailable</body></html>"),
+		)
+
+		_, err = client.Update(ctx, "unavailable", JSON, bytes.NewBufferString("[]"))
+		require.Error(t, err)
+		assert.True(t, IsUnavailable(err))
+	})
+
+	t.Run("close lb client", func(t *testing.T) {
+		nodes := []string{"https://node1.example.com", "https://node2.example.com"}
+		for _, node := range nodes {
+			httpmock.RegisterResponder(http.MethodGet, node+"/solr/admin/cores",
+				httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+			httpmock.RegisterResponder(http.MethodGet, node+"/solr/admin/info/system",
+				httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+		}
+
+		lbClient := NewLBJSONClient(nodes...)
+		lbClient.lb.pingInterval = 10 * time.Millisecond
+
+		_, err := lbClient.CoreStatus(ctx, NewCoreParams("mycore"))
+		require.Error(t, err)
+		assert.True(t, IsUnavailable(err))
+
+		pings := func() int {
+			return httpmock.GetCallCountInfo()["GET "+nodes[0]+"/solr/admin/info/system"]
+		}
+		require.Eventually(t, func() bool { return pings() > 0 }, time.Second, 10*time.Millisecond)
+
+		lbClient.Close()
+		lbClient.Close()
+
+		// the zombies are no longer pinged
+		time.Sleep(30 * time.Millisecond)
+		stopped := pings()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, pings())
+
+		// no-op for the other clients
+		client.Close()
+	})
+
 	t.Run("unexpected html", func(t *testing.T) {
 		httpmock.RegisterResponder(http.MethodGet, baseURL+"/solr/admin/cores", func(r *http.Request) (*http.Response, error) {
 			response := httpmock.NewBytesResponse(http.StatusUnauthorized, []byte("<html><title>Unauthorized</html>"))
@@ -0,0 +1,269 @@
+package solr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// errNoNodes is returned when there are no nodes to send the request to
+var errNoNodes = errors.New("no nodes available")
+
+// LBRequestSender is a load-balancing request sender similar to SolrJ's LBHttpSolrClient.
+// Requests are distributed across the nodes in a round-robin fashion. Nodes that fail
+// are marked as zombies and are periodically pinged until they become alive again.
+//
+// The scheme and host of the request URL are replaced with the base URL of the chosen
+// node, so it can be used with a JSONClient with any (or an empty) base URL.
+type LBRequestSender struct {
+	// reqSender is the underlying request sender
+	reqSender RequestSender
+	// pingPath is the path used to check if a zombie node is alive
+	pingPath string
+	// pingInterval is the interval between zombie checks
+	pingInterval time.Duration
+
+	// counter is the round-robin counter
+	counter atomic.Uint64
+
+	mu sync.Mutex
+	// alive is the list of alive nodes
+	alive []string
+	// zombies is the list of failed nodes
+	zombies []string
+
+	checkerOnce sync.Once
+	closeOnce   sync.Once
+	done        chan struct{}
+}
+
+var _ RequestSender = (*LBRequestSender)(nil)
+
+// NewLBRequestSender takes the underlying request sender and
+// the base URLs of the nodes and returns a new LBRequestSender.
+//
+// The zombies are pinged with the node-level /solr/admin/info/system handler by default
+// because the /admin/ping handler is per collection or core and the sender is not tied
+// to one. Use WithPingCollection to ping the /admin/ping handler of a collection instead.
+func NewLBRequestSender(reqSender RequestSender, baseURLs ...string) *LBRequestSender {
+	alive := make([]string, 0, len(baseURLs))
+	for _, baseURL := range baseURLs {
+		alive = append(alive, strings.TrimSuffix(baseURL, "/"))
+	}
+
+	return &LBRequestSender{
+		reqSender:    reqSender,
+		pingPath:     "/solr/admin/info/system",
+		pingInterval: time.Minute,
+		alive:        alive,
+		done:         make(chan struct{}),
+	}
+}
+
+// WithPingPath overrides the path used to check if a zombie node is alive.
+// For example, use "/solr/<collection>/admin/ping" to use the ping request handler.
+func (rs *LBRequestSender) WithPingPath(pingPath string) *LBRequestSender {
+	rs.pingPath = pingPath
+	return rs
+}
+
+// WithPingCollection pings the /admin/ping handler of the collection or core to check
+// if a zombie node is alive i.e. "/solr/<collection>/admin/ping"
+func (rs *LBRequestSender) WithPingCollection(collection string) *LBRequestSender {
+	rs.pingPath = "/solr/" + url.PathEscape(collection) + "/admin/ping"
+	return rs
+}
+
+// WithPingInterval overrides the interval between zombie checks
+func (rs *LBRequestSender) WithPingInterval(pingInterval time.Duration) *LBRequestSender {
+	rs.pingInterval = pingInterval
+	return rs
+}
+
+// SendRequest sends the request to the next alive node, failing over to the other nodes.
+// Non-idempotent requests e.g. updates are only failed over when the connection to
+// the node couldn't be established since the node might have applied them.
+func (rs *LBRequestSender) SendRequest(ctx context.Context, httpMethod,
+	urlStr, contentType string, body io.Reader) (*http.Response, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, wrapErr(err, "parse url")
+	}
+
+	newBody, err := bufferBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := rs.nodes()
+	if len(nodes) == 0 {
+		return nil, errNoNodes
+	}
+
+	idempotent := isIdempotent(httpMethod, urlStr)
+
+	var (
+		httpResp *http.Response
+		lastErr  error
+	)
+	for i, node := range nodes {
+		httpResp, err = rs.reqSender.SendRequest(ctx, httpMethod,
+			node+u.RequestURI(), contentType, newBody())
+		if err == nil && (!idempotent || httpResp.StatusCode < http.StatusInternalServerError) {
+			rs.markAlive(node)
+			return httpResp, nil
+		}
+
+		// the caller gave up, it's not the node's fault
+		if ctx.Err() != nil {
+			return httpResp, err
+		}
+
+		rs.markZombie(node)
+		lastErr = err
+
+		// the node might have applied a non-idempotent request,
+		// only fail over if the connection couldn't be established
+		if err != nil && !idempotent && !isDialError(err) {
+			return nil, err
+		}
+
+		if httpResp != nil && i < len(nodes)-1 {
+			drainAndClose(httpResp)
+		}
+	}
+
+	return httpResp, lastErr
+}
+
+// Close stops the zombie checker
+func (rs *LBRequestSender) Close() {
+	rs.closeOnce.Do(func() { close(rs.done) })
+}
+
+// nodes returns the alive nodes, starting with the next one in round-robin
+// order, followed by the zombies as the last resort
+func (rs *LBRequestSender) nodes() []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	nodes := make([]string, 0, len(rs.alive)+len(rs.zombies))
+	if n := len(rs.alive); n > 0 {
+		start := int(rs.counter.Add(1) % uint64(n))
+		nodes = append(nodes, rs.alive[start:]...)
+		nodes = append(nodes, rs.alive[:start]...)
+	}
+
+	return append(nodes, rs.zombies...)
+}
+
+// addNodes adds the nodes that are not yet known to the alive list
+func (rs *LBRequestSender) addNodes(nodes ...string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	known := map[string]bool{}
+	for _, node := range rs.alive {
+		known[node] = true
+	}
+	for _, node := range rs.zombies {
+		known[node] = true
+	}
+
+	for _, node := range nodes {
+		node = strings.TrimSuffix(node, "/")
+		if !known[node] {
+			known[node] = true
+			rs.alive = append(rs.alive, node)
+		}
+	}
+}
+
+// markAlive moves the node from the zombies to the alive list
+func (rs *LBRequestSender) markAlive(node string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var ok bool
+	rs.zombies, ok = removeNode(rs.zombies, node)
+	if ok {
+		rs.alive = append(rs.alive, node)
+	}
+}
+
+// markZombie moves the node from the alive to the zombies list
+func (rs *LBRequestSender) markZombie(node string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var ok bool
+	rs.alive, ok = removeNode(rs.alive, node)
+	if ok {
+		rs.zombies = append(rs.zombies, node)
+	}
+
+	rs.checkerOnce.Do(func() { go rs.checkZombies() })
+}
+
+// checkZombies periodically pings the zombies until the sender is closed
+func (rs *LBRequestSender) checkZombies() {
+	ticker := time.NewTicker(rs.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rs.done:
+			return
+		case <-ticker.C:
+		}
+
+		rs.mu.Lock()
+		zombies := append([]string{}, rs.zombies...)
+		rs.mu.Unlock()
+
+		for _, zombie := range zombies {
+			if rs.ping(zombie) {
+				rs.markAlive(zombie)
+			}
+		}
+	}
+}
+
+// ping returns true if the node is alive
+func (rs *LBRequestSender) ping(node string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), rs.pingInterval)
+	defer cancel()
+
+	httpResp, err := rs.reqSender.SendRequest(ctx, http.MethodGet,
+		node+rs.pingPath, JSON.String(), nil)
+	if err != nil {
+		return false
+	}
+	drainAndClose(httpResp)
+
+	return httpResp.StatusCode < http.StatusInternalServerError
+}
+
+// isDialError returns true if the connection couldn't be established i.e. the request wasn't sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// removeNode removes the node from the list
+func removeNode(nodes []string, node string) ([]string, bool) {
+	for i, n := range nodes {
+		if n == node {
+			return append(nodes[:i], nodes[i+1:]...), true
+		}
+	}
+
+	return nodes, false
+}
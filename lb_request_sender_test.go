@@ -0,0 +1,198 @@
+package solr_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// newTestNode returns a test server that counts the requests and
+// replies with service unavailable while down is set
+func newTestNode(hits, pings *int32, down *atomic.Value) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/admin/ping") {
+			atomic.AddInt32(pings, 1)
+		} else {
+			atomic.AddInt32(hits, 1)
+		}
+
+		if down.Load().(bool) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"responseHeader":{"status":0}}`))
+	}))
+}
+
+func TestLBRequestSender(t *testing.T) {
+	ctx := context.Background()
+
+	var hits1, hits2, pings1, pings2 int32
+	var down1, down2 atomic.Value
+	down1.Store(false)
+	down2.Store(false)
+
+	node1 := newTestNode(&hits1, &pings1, &down1)
+	defer node1.Close()
+	node2 := newTestNode(&hits2, &pings2, &down2)
+	defer node2.Close()
+
+	rs := solr.NewLBRequestSender(solr.NewDefaultRequestSender(), node1.URL, node2.URL+"/").
+		WithPingPath("/solr/products/admin/ping").
+		WithPingInterval(10 * time.Millisecond)
+	defer rs.Close()
+
+	client := solr.NewJSONClient("").WithRequestSender(rs)
+
+	t.Run("round robin", func(t *testing.T) {
+		for i := 0; i < 4; i++ {
+			err := client.CreateCore(ctx, solr.NewCreateCoreParams("mycore"))
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits1))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits2))
+	})
+
+	t.Run("fail over", func(t *testing.T) {
+		atomic.StoreInt32(&hits1, 0)
+		atomic.StoreInt32(&hits2, 0)
+		down1.Store(true)
+
+		for i := 0; i < 4; i++ {
+			err := client.CreateCore(ctx, solr.NewCreateCoreParams("mycore"))
+			require.NoError(t, err)
+		}
+
+		// node1 is only tried once before being marked as zombie
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits1))
+		assert.Equal(t, int32(4), atomic.LoadInt32(&hits2))
+	})
+
+	t.Run("revive zombie", func(t *testing.T) {
+		down1.Store(false)
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&pings1) > 0
+		}, time.Second, 10*time.Millisecond)
+
+		// wait for node1 to be marked as alive
+		time.Sleep(50 * time.Millisecond)
+
+		atomic.StoreInt32(&hits1, 0)
+		atomic.StoreInt32(&hits2, 0)
+		for i := 0; i < 4; i++ {
+			err := client.CreateCore(ctx, solr.NewCreateCoreParams("mycore"))
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits1))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits2))
+	})
+
+	t.Run("all nodes down", func(t *testing.T) {
+		down1.Store(true)
+		down2.Store(true)
+
+		err := client.CreateCore(ctx, solr.NewCreateCoreParams("mycore"))
+		assert.True(t, solr.IsUnavailable(err))
+
+		down1.Store(false)
+		down2.Store(false)
+	})
+
+	t.Run("non-idempotent requests", func(t *testing.T) {
+		var hits, pings int32
+		var down atomic.Value
+		down.Store(true)
+		node := newTestNode(&hits, &pings, &down)
+		defer node.Close()
+
+		rs := solr.NewLBRequestSender(solr.NewDefaultRequestSender(), node.URL, node.URL)
+		defer rs.Close()
+
+		// updates are not failed over on error responses
+		_, err := solr.NewJSONClient("").WithRequestSender(rs).
+			Update(ctx, "products", solr.JSON, strings.NewReader(`[]`))
+		assert.True(t, solr.IsUnavailable(err))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	})
+
+	t.Run("non-idempotent requests after connection errors", func(t *testing.T) {
+		// the connection is closed after the request was received
+		var hits int32
+		broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+		}))
+		defer broken.Close()
+
+		rs := solr.NewLBRequestSender(solr.NewDefaultRequestSender(), broken.URL, broken.URL)
+		defer rs.Close()
+
+		_, err := solr.NewJSONClient("").WithRequestSender(rs).
+			Update(ctx, "products", solr.JSON, strings.NewReader(`[]`))
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+		// failed over if the connection couldn't be established
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		rs = solr.NewLBRequestSender(solr.NewDefaultRequestSender(), closed.URL, node1.URL)
+		defer rs.Close()
+
+		for i := 0; i < 2; i++ {
+			_, err = solr.NewJSONClient("").WithRequestSender(rs).
+				Update(ctx, "products", solr.JSON, strings.NewReader(`[]`))
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("ping collection", func(t *testing.T) {
+		var hits, pings int32
+		var down atomic.Value
+		down.Store(true)
+		node := newTestNode(&hits, &pings, &down)
+		defer node.Close()
+
+		rs := solr.NewLBRequestSender(solr.NewDefaultRequestSender(), node.URL).
+			WithPingCollection("products").
+			WithPingInterval(10 * time.Millisecond)
+		defer rs.Close()
+
+		_, err := solr.NewJSONClient("").WithRequestSender(rs).
+			Query(ctx, "products", solr.NewQuery("*:*"))
+		assert.True(t, solr.IsUnavailable(err))
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&pings) > 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("no nodes", func(t *testing.T) {
+		rs := solr.NewLBRequestSender(solr.NewDefaultRequestSender())
+		_, err := rs.SendRequest(ctx, http.MethodGet, "/solr/admin/cores", solr.JSON.String(), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("new lb json client", func(t *testing.T) {
+		client := solr.NewLBJSONClient(node1.URL, node2.URL)
+		defer client.Close()
+		err := client.CreateCore(ctx, solr.NewCreateCoreParams("mycore"))
+		assert.NoError(t, err)
+	})
+}
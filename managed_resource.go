@@ -0,0 +1,68 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ManagedResource is a resource registered with the REST manager e.g. a managed stopwords list
+type ManagedResource struct {
+	// ResourceID is the resource path e.g. "/schema/analysis/stopwords/english"
+	ResourceID   string `json:"resourceId"`
+	Class        string `json:"class"`
+	NumObservers int    `json:"numObservers"`
+}
+
+// UnmarshalJSON accepts numObservers as a number or as a string
+func (r *ManagedResource) UnmarshalJSON(b []byte) error {
+	type alias ManagedResource
+	var v struct {
+		alias
+		NumObservers json.RawMessage `json:"numObservers"`
+	}
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+
+	*r = ManagedResource(v.alias)
+	if len(v.NumObservers) > 0 {
+		r.NumObservers, err = strconv.Atoi(strings.Trim(string(v.NumObservers), `"`))
+		if err != nil {
+			return fmt.Errorf("invalid numObservers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ManagedInitArgs is the init args of a managed stopwords list or synonym map
+type ManagedInitArgs struct {
+	IgnoreCase bool `json:"ignoreCase"`
+	// Format is the synonym format e.g. "solr"
+	Format string `json:"format,omitempty"`
+}
+
+// ManagedWordSet is a managed stopwords list
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-stop-words
+type ManagedWordSet struct {
+	InitArgs         ManagedInitArgs `json:"initArgs"`
+	InitializedOn    string          `json:"initializedOn"`
+	UpdatedSinceInit string          `json:"updatedSinceInit,omitempty"`
+	// ManagedList is the stopwords
+	ManagedList []string `json:"managedList"`
+}
+
+// ManagedSynonymMap is a managed synonym map
+//
+// Refer to https://solr.apache.org/guide/8_8/managed-resources.html#managing-synonyms
+type ManagedSynonymMap struct {
+	InitArgs         ManagedInitArgs `json:"initArgs"`
+	InitializedOn    string          `json:"initializedOn"`
+	UpdatedSinceInit string          `json:"updatedSinceInit,omitempty"`
+	// ManagedMap is the synonyms by term
+	ManagedMap map[string][]string `json:"managedMap"`
+}
@@ -0,0 +1,42 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestManagedResourceUnmarshal(t *testing.T) {
+	var resources []solr.ManagedResource
+	err := json.Unmarshal([]byte(`[
+		{"resourceId":"/schema/analysis/stopwords/english","class":"org.apache.solr.rest.schema.analysis.ManagedWordSetResource","numObservers":"1"},
+		{"resourceId":"/schema/analysis/synonyms/english","class":"org.apache.solr.rest.schema.analysis.ManagedSynonymGraphFilterFactory$SynonymManager","numObservers":2},
+		{"resourceId":"/config/managed","class":"org.apache.solr.rest.ManagedResource"}
+	]`), &resources)
+	require.NoError(t, err)
+
+	assert.Equal(t, []solr.ManagedResource{
+		{
+			ResourceID:   "/schema/analysis/stopwords/english",
+			Class:        "org.apache.solr.rest.schema.analysis.ManagedWordSetResource",
+			NumObservers: 1,
+		},
+		{
+			ResourceID:   "/schema/analysis/synonyms/english",
+			Class:        "org.apache.solr.rest.schema.analysis.ManagedSynonymGraphFilterFactory$SynonymManager",
+			NumObservers: 2,
+		},
+		{
+			ResourceID: "/config/managed",
+			Class:      "org.apache.solr.rest.ManagedResource",
+		},
+	}, resources)
+
+	var resource solr.ManagedResource
+	err = json.Unmarshal([]byte(`{"resourceId":"/schema/analysis/stopwords/english","numObservers":"one"}`), &resource)
+	assert.Error(t, err)
+}
@@ -8,6 +8,7 @@ const (
 	JSON MimeType = iota
 	XML
 	CSV
+	FormURLEncoded
 )
 
 // String implements Stringer
@@ -16,5 +17,6 @@ func (mt MimeType) String() string {
 		"application/json",
 		"application/xml",
 		"text/csv",
+		"application/x-www-form-urlencoded",
 	}[mt]
 }
@@ -25,6 +25,10 @@ func TestContentTypeStringer(t *testing.T) {
 			solr.CSV,
 			"text/csv",
 		},
+		{
+			solr.FormURLEncoded,
+			"application/x-www-form-urlencoded",
+		},
 	}
 
 	for _, test := range tests {
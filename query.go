@@ -1,5 +1,7 @@
 package solr
 
+import "strings"
+
 // Query is a query
 type Query struct {
 	// common query params
@@ -24,6 +26,14 @@ type Query struct {
 	fields  []string // fl
 	params  M        // additional params to add verbatim to request query params
 
+	// cursorMark is the cursor for deep paging
+	// Refer to https://solr.apache.org/guide/8_8/pagination-of-results.html#fetching-a-large-number-of-sorted-results-cursors
+	cursorMark string
+
+	// useParams is the paramsets to use
+	// Refer to https://solr.apache.org/guide/8_8/request-parameters-api.html
+	useParams []string
+
 	// query is the main query
 	query string
 
@@ -70,8 +80,21 @@ func (q *Query) BuildQuery() M {
 		qm["fields"] = q.fields
 	}
 
-	if len(q.params) > 0 {
-		qm["params"] = q.params
+	if len(q.params) > 0 || q.cursorMark != "" || len(q.useParams) > 0 {
+		params := M{}
+		for k, v := range q.params {
+			params[k] = v
+		}
+
+		if q.cursorMark != "" {
+			params["cursorMark"] = q.cursorMark
+		}
+
+		if len(q.useParams) > 0 {
+			params["useParams"] = strings.Join(q.useParams, ",")
+		}
+
+		qm["params"] = params
 	}
 
 	if len(q.facets) > 0 {
@@ -122,6 +145,20 @@ func (q *Query) Params(params M) *Query {
 	return q
 }
 
+// CursorMark sets the cursorMark param, use "*" to fetch the first page.
+// The sort must include the uniqueKey field when using a cursor.
+func (q *Query) CursorMark(cursorMark string) *Query {
+	q.cursorMark = cursorMark
+	return q
+}
+
+// UseParams sets the paramsets to use, the params of the later
+// paramsets take precedence over the earlier ones
+func (q *Query) UseParams(names ...string) *Query {
+	q.useParams = names
+	return q
+}
+
 // Facets sets the facet query
 func (q *Query) Facets(facets ...Faceter) *Query {
 	q.facets = facets
@@ -0,0 +1,121 @@
+package solr
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// QueryIterator iterates over all the documents matching a query using cursors
+// for deep paging. A page is fetched whenever the documents from the previous page
+// are exhausted until the cursor stops changing. The limit of the query is
+// used as the page size and the uniqueKey is added to the sort as the tiebreaker.
+// The iteration starts from the cursor mark of the query if set.
+//
+// Refer to https://solr.apache.org/guide/8_8/pagination-of-results.html#fetching-a-large-number-of-sorted-results-cursors
+type QueryIterator struct {
+	client     Client
+	collection string
+	query      Query
+	// uniqueKey is the uniqueKey field used as the sort tiebreaker
+	uniqueKey string
+
+	// docs is the remaining documents from the current page
+	docs []M
+	// doc is the current document
+	doc M
+	// started is true once the first page is fetched
+	started bool
+	done    bool
+	err     error
+}
+
+// NewQueryIterator returns a new QueryIterator
+func NewQueryIterator(client Client, collection string, query *Query) *QueryIterator {
+	return &QueryIterator{
+		client:     client,
+		collection: collection,
+		query:      *query,
+		uniqueKey:  "id",
+	}
+}
+
+// WithUniqueKey overrides the uniqueKey field used as the sort tiebreaker, defaults to "id"
+func (it *QueryIterator) WithUniqueKey(uniqueKey string) *QueryIterator {
+	it.uniqueKey = uniqueKey
+	return it
+}
+
+// Next advances the iterator to the next document. It returns false when
+// there are no more documents or when an error occurred.
+func (it *QueryIterator) Next(ctx context.Context) bool {
+	for len(it.docs) == 0 {
+		if it.done || it.err != nil {
+			it.doc = nil
+			return false
+		}
+
+		it.err = it.fetch(ctx)
+	}
+
+	it.doc, it.docs = it.docs[0], it.docs[1:]
+	return true
+}
+
+// Document returns the current document
+func (it *QueryIterator) Document() M {
+	return it.doc
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *QueryIterator) Err() error {
+	return it.err
+}
+
+// fetch fetches the next page
+func (it *QueryIterator) fetch(ctx context.Context) error {
+	q := &it.query
+	if !it.started {
+		if q.offset != 0 {
+			return errors.New("offset cannot be used with cursors")
+		}
+
+		// the tiebreaker is also needed when resuming from the cursor of the query
+		q.sort = withTiebreaker(q.sort, it.uniqueKey)
+		if q.cursorMark == "" {
+			q.cursorMark = "*"
+		}
+		it.started = true
+	}
+
+	resp, err := it.client.Query(ctx, it.collection, q)
+	if err != nil {
+		return wrapErr(err, "query")
+	}
+
+	it.docs = resp.Response.Documents
+
+	// we've reached the end if the cursor didn't change
+	if resp.NextCursorMark == "" || resp.NextCursorMark == q.cursorMark {
+		it.done = true
+	}
+	q.cursorMark = resp.NextCursorMark
+
+	return nil
+}
+
+// withTiebreaker adds the uniqueKey to the sort if it's not included yet
+func withTiebreaker(sort, uniqueKey string) string {
+	if sort == "" {
+		return "score desc," + uniqueKey + " asc"
+	}
+
+	for _, clause := range strings.Split(sort, ",") {
+		fields := strings.Fields(clause)
+		if len(fields) > 0 && fields[0] == uniqueKey {
+			return sort
+		}
+	}
+
+	return sort + "," + uniqueKey + " asc"
+}
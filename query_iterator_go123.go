@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package solr
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns an iterator over all the documents. The iteration stops
+// after yielding the error if fetching a page fails.
+func (it *QueryIterator) All(ctx context.Context) iter.Seq2[M, error] {
+	return func(yield func(M, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Document(), nil) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
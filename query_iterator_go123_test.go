@@ -0,0 +1,47 @@
+//go:build go1.23
+
+package solr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestQueryIteratorAll(t *testing.T) {
+	ctx := context.Background()
+	docs := []solr.M{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+
+	var sorts []string
+	server := newCursorServer(t, docs, &sorts)
+	defer server.Close()
+
+	client := solr.NewJSONClient(server.URL)
+	query := solr.NewQuery("*:*").Limit(2)
+
+	got := []solr.M{}
+	for doc, err := range solr.NewQueryIterator(client, "products", query).All(ctx) {
+		require.NoError(t, err)
+		got = append(got, doc)
+	}
+	assert.Equal(t, docs, got)
+
+	// stop early
+	got = []solr.M{}
+	for doc, err := range solr.NewQueryIterator(client, "products", query).All(ctx) {
+		require.NoError(t, err)
+		got = append(got, doc)
+		break
+	}
+	assert.Equal(t, docs[:1], got)
+
+	// errors are yielded
+	it := solr.NewQueryIterator(client, "products", solr.NewQuery("*:*").Offset(1))
+	for _, err := range it.All(ctx) {
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,149 @@
+package solr_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// newCursorServer returns a test server that pages through the
+// documents using the cursor mark as the offset
+func newCursorServer(t *testing.T, docs []solr.M, sorts *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Sort   string `json:"sort"`
+			Limit  int    `json:"limit"`
+			Params solr.M `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*sorts = append(*sorts, body.Sort)
+
+		cursorMark, _ := body.Params["cursorMark"].(string)
+		start := 0
+		if cursorMark != "*" {
+			start, _ = strconv.Atoi(cursorMark)
+		}
+
+		end := start + body.Limit
+		if end > len(docs) {
+			end = len(docs)
+		}
+
+		nextCursorMark := strconv.Itoa(end)
+		if start == end {
+			nextCursorMark = cursorMark
+		}
+
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(solr.M{
+			"response":       solr.M{"numFound": len(docs), "docs": docs[start:end]},
+			"nextCursorMark": nextCursorMark,
+		})
+	}))
+}
+
+func TestQueryIterator(t *testing.T) {
+	ctx := context.Background()
+	docs := []solr.M{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}, {"id": "5"}}
+
+	t.Run("iterate all documents", func(t *testing.T) {
+		var sorts []string
+		server := newCursorServer(t, docs, &sorts)
+		defer server.Close()
+
+		client := solr.NewJSONClient(server.URL)
+		query := solr.NewQuery("*:*").Sort("name asc").Limit(2)
+		it := solr.NewQueryIterator(client, "products", query)
+
+		got := []solr.M{}
+		for it.Next(ctx) {
+			got = append(got, it.Document())
+		}
+		require.NoError(t, it.Err())
+		assert.Equal(t, docs, got)
+		assert.Nil(t, it.Document())
+
+		// 3 pages and a last request to check that the cursor didn't change
+		assert.Equal(t, []string{"name asc,id asc", "name asc,id asc", "name asc,id asc", "name asc,id asc"}, sorts)
+
+		// the original query is not modified
+		assert.Equal(t, "name asc", query.BuildQuery()["sort"])
+	})
+
+	t.Run("sort tiebreaker", func(t *testing.T) {
+		var tests = []struct {
+			sort, uniqueKey, expect string
+		}{
+			{"", "id", "score desc,id asc"},
+			{"id desc", "id", "id desc"},
+			{"name asc, id desc", "id", "name asc, id desc"},
+			{"name asc", "sku", "name asc,sku asc"},
+		}
+
+		for _, test := range tests {
+			var sorts []string
+			server := newCursorServer(t, nil, &sorts)
+
+			query := solr.NewQuery("*:*").Sort(test.sort).Limit(2)
+			it := solr.NewQueryIterator(solr.NewJSONClient(server.URL), "products", query).
+				WithUniqueKey(test.uniqueKey)
+			for it.Next(ctx) {
+			}
+			require.NoError(t, it.Err())
+			assert.Equal(t, []string{test.expect}, sorts)
+
+			server.Close()
+		}
+	})
+
+	t.Run("initial cursor mark", func(t *testing.T) {
+		var sorts []string
+		server := newCursorServer(t, docs, &sorts)
+		defer server.Close()
+
+		for _, test := range []struct {
+			cursorMark string
+			start      int
+		}{{"*", 0}, {"2", 2}} {
+			sorts = nil
+			query := solr.NewQuery("*:*").Sort("price desc").CursorMark(test.cursorMark).Limit(5)
+			it := solr.NewQueryIterator(solr.NewJSONClient(server.URL), "products", query)
+
+			got := []solr.M{}
+			for it.Next(ctx) {
+				got = append(got, it.Document())
+			}
+			require.NoError(t, it.Err())
+
+			assert.Equal(t, docs[test.start:], got)
+			assert.Equal(t, []string{"price desc,id asc", "price desc,id asc"}, sorts)
+		}
+	})
+
+	t.Run("offset is not allowed", func(t *testing.T) {
+		query := solr.NewQuery("*:*").Offset(10)
+		it := solr.NewQueryIterator(solr.NewJSONClient("http://localhost"), "products", query)
+		assert.False(t, it.Next(ctx))
+		assert.Error(t, it.Err())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"msg":"Cursor functionality requires a sort containing a uniqueKey field tie breaker","code":400}}`))
+		}))
+		defer server.Close()
+
+		it := solr.NewQueryIterator(solr.NewJSONClient(server.URL), "products", solr.NewQuery("*:*"))
+		assert.False(t, it.Next(ctx))
+		assert.True(t, solr.IsBadRequest(it.Err()))
+	})
+}
@@ -12,6 +12,16 @@ type QueryParser interface {
 	BuildParser() string
 }
 
+// RawQuery is a raw query string e.g. "category:books"
+type RawQuery string
+
+var _ QueryParser = RawQuery("")
+
+// BuildParser returns the raw query
+func (q RawQuery) BuildParser() string {
+	return string(q)
+}
+
 // StandardQueryParser is a standard query parser (lucene)
 type StandardQueryParser struct {
 	// standard q parser params
@@ -93,3 +93,39 @@ func TestQueryParamsArbitraryArgs(t *testing.T) {
 
 	a.Equal(expect, got)
 }
+
+func TestQueryCursorMark(t *testing.T) {
+	a := assert.New(t)
+	got := solr.NewQuery("*:*").
+		Sort("id asc").
+		Params(solr.M{"spellcheck.q": "sports"}).
+		CursorMark("*").
+		BuildQuery()
+
+	expect := solr.M{
+		"params": solr.M{
+			"spellcheck.q": "sports",
+			"cursorMark":   "*",
+		},
+		"query": "*:*",
+		"sort":  "id asc",
+	}
+
+	a.Equal(expect, got)
+}
+
+func TestQueryUseParams(t *testing.T) {
+	a := assert.New(t)
+	got := solr.NewQuery("*:*").
+		UseParams("myQueries", "myFacets").
+		BuildQuery()
+
+	expect := solr.M{
+		"params": solr.M{
+			"useParams": "myQueries,myFacets",
+		},
+		"query": "*:*",
+	}
+
+	a.Equal(expect, got)
+}
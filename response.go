@@ -1,6 +1,12 @@
 package solr
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // BaseResponse is the base response
 type BaseResponse struct {
@@ -20,12 +26,64 @@ type ResponseError struct {
 	Code     int      `json:"code"`
 	Metadata []string `json:"metadata"`
 	Msg      string   `json:"msg"`
+	Trace    string   `json:"trace,omitempty"`
+	// Details is the errors of the individual Config and Schema API commands
+	Details []CommandError `json:"details,omitempty"`
 }
 
 func (e ResponseError) Error() string {
 	return e.Msg
 }
 
+// CommandError is the error of a Config or Schema API command
+type CommandError struct {
+	// Command is the command name e.g. "add-requesthandler"
+	Command string
+	// Body is the command body
+	Body interface{}
+	// Messages is the error messages of the command
+	Messages []string
+}
+
+func (e CommandError) Error() string {
+	return e.Command + ": " + strings.Join(e.Messages, ", ")
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The command is
+// encoded as {"<command>": <body>, "errorMessages": [...]}
+func (e *CommandError) UnmarshalJSON(b []byte) error {
+	var m map[string]json.RawMessage
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range m {
+		if k == "errorMessages" {
+			err = json.Unmarshal(v, &e.Messages)
+		} else {
+			e.Command = k
+			err = json.Unmarshal(v, &e.Body)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e CommandError) MarshalJSON() ([]byte, error) {
+	m := M{"errorMessages": e.Messages}
+	if e.Command != "" {
+		m[e.Command] = e.Body
+	}
+
+	return json.Marshal(m)
+}
+
 // UpdateResponse is an update response
 type UpdateResponse struct {
 	*BaseResponse
@@ -34,8 +92,9 @@ type UpdateResponse struct {
 // QueryResponse is a query response
 type QueryResponse struct {
 	*BaseResponse
-	Response QueryResponseBody `json:"response,omitempty"`
-	Facets   M                 `json:"facets,omitempty"`
+	Response       QueryResponseBody `json:"response,omitempty"`
+	Facets         M                 `json:"facets,omitempty"`
+	NextCursorMark string            `json:"nextCursorMark,omitempty"`
 }
 
 // QueryResponseBody is the query response body
@@ -44,6 +103,31 @@ type QueryResponseBody struct {
 	Start     int     `json:"start,omitempty"`
 	MaxScore  float64 `json:"maxScore,omitempty"`
 	Documents []M     `json:"docs,omitempty"`
+
+	// rawDocuments is the undecoded documents
+	rawDocuments []json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The undecoded documents
+// are kept so that QueryInto can decode the long values exactly.
+func (b *QueryResponseBody) UnmarshalJSON(data []byte) error {
+	// alias to avoid recursing into UnmarshalJSON
+	type queryResponseBody QueryResponseBody
+	err := json.Unmarshal(data, (*queryResponseBody)(b))
+	if err != nil {
+		return err
+	}
+
+	var raw struct {
+		Documents []json.RawMessage `json:"docs"`
+	}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	b.rawDocuments = raw.Documents
+
+	return nil
 }
 
 // SuggestResponse is the suggester response
@@ -72,6 +156,57 @@ type Suggestion struct {
 	Payload string `json:"payload,omitempty"`
 }
 
+// ClusterStatusResponse is the cluster status response
+type ClusterStatusResponse struct {
+	*BaseResponse
+	Cluster *ClusterStatus `json:"cluster"`
+}
+
+// ClusterStatus is the SolrCloud cluster status
+type ClusterStatus struct {
+	Collections map[string]*CollectionState `json:"collections"`
+	Aliases     map[string]string           `json:"aliases,omitempty"`
+	LiveNodes   []string                    `json:"live_nodes"`
+}
+
+// CollectionState is the state of a collection
+type CollectionState struct {
+	Shards       map[string]*ShardState `json:"shards"`
+	Router       *DocRouterSpec         `json:"router"`
+	ConfigName   string                 `json:"configName"`
+	Health       string                 `json:"health"`
+	ZNodeVersion int                    `json:"znodeVersion"`
+}
+
+// DocRouterSpec is the document router of a collection
+type DocRouterSpec struct {
+	Name  string `json:"name"`
+	Field string `json:"field,omitempty"`
+}
+
+// ShardState is the state of a shard
+type ShardState struct {
+	Range    string                   `json:"range"`
+	State    string                   `json:"state"`
+	Health   string                   `json:"health"`
+	Replicas map[string]*ReplicaState `json:"replicas"`
+}
+
+// ReplicaState is the state of a replica
+type ReplicaState struct {
+	Core     string `json:"core"`
+	BaseURL  string `json:"base_url"`
+	NodeName string `json:"node_name"`
+	State    string `json:"state"`
+	Type     string `json:"type"`
+	Leader   string `json:"leader,omitempty"`
+}
+
+// IsLeader returns true if the replica is the shard leader
+func (r *ReplicaState) IsLeader() bool {
+	return r.Leader == "true"
+}
+
 // CoreStatusResponse is the core status response
 type CoreStatusResponse struct {
 	*BaseResponse
@@ -107,3 +242,184 @@ type Index struct {
 	UserData                M      `json:"userData"`
 	Version                 int
 }
+
+// ConfigResponse is the config response
+type ConfigResponse struct {
+	*BaseResponse
+	Config *SolrConfig `json:"config"`
+}
+
+// SolrConfig is the effective solrconfig.xml including the overlay. When a
+// single section is requested, only that section is populated.
+type SolrConfig struct {
+	LuceneMatchVersion string                           `json:"luceneMatchVersion,omitempty"`
+	UpdateHandler      *UpdateHandlerConfig             `json:"updateHandler,omitempty"`
+	Query              *QueryConfig                     `json:"query,omitempty"`
+	RequestHandlers    map[string]*RequestHandlerConfig `json:"requestHandler,omitempty"`
+	// Raw is the whole config including the sections that don't have a typed struct
+	Raw M `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *SolrConfig) UnmarshalJSON(b []byte) error {
+	// alias to avoid recursing into UnmarshalJSON
+	type solrConfig SolrConfig
+	err := json.Unmarshal(b, (*solrConfig)(c))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, &c.Raw)
+}
+
+// UpdateHandlerConfig is the update handler config
+type UpdateHandlerConfig struct {
+	Class          string              `json:"class,omitempty"`
+	AutoCommit     *AutoCommitConfig   `json:"autoCommit,omitempty"`
+	AutoSoftCommit *AutoCommitConfig   `json:"autoSoftCommit,omitempty"`
+	IndexWriter    *IndexWriterConfig  `json:"indexWriter,omitempty"`
+	CommitWithin   *CommitWithinConfig `json:"commitWithin,omitempty"`
+}
+
+// IndexWriterConfig is the index writer config of the update handler
+type IndexWriterConfig struct {
+	CloseWaitsForMerges bool `json:"closeWaitsForMerges"`
+}
+
+// CommitWithinConfig is the commitWithin config of the update handler
+type CommitWithinConfig struct {
+	SoftCommit bool `json:"softCommit"`
+}
+
+// AutoCommitConfig is the autoCommit or autoSoftCommit config, -1 means disabled
+type AutoCommitConfig struct {
+	MaxDocs int `json:"maxDocs"`
+	// MaxTime is in milliseconds
+	MaxTime      int  `json:"maxTime"`
+	OpenSearcher bool `json:"openSearcher,omitempty"`
+}
+
+// QueryConfig is the query section of the config
+type QueryConfig struct {
+	MaxBooleanClauses        int          `json:"maxBooleanClauses,omitempty"`
+	UseFilterForSortedQuery  bool         `json:"useFilterForSortedQuery"`
+	QueryResultWindowSize    int          `json:"queryResultWindowSize,omitempty"`
+	QueryResultMaxDocsCached int          `json:"queryResultMaxDocsCached,omitempty"`
+	EnableLazyFieldLoading   bool         `json:"enableLazyFieldLoading"`
+	FilterCache              *CacheConfig `json:"filterCache,omitempty"`
+	QueryResultCache         *CacheConfig `json:"queryResultCache,omitempty"`
+	DocumentCache            *CacheConfig `json:"documentCache,omitempty"`
+	FieldValueCache          *CacheConfig `json:"fieldValueCache,omitempty"`
+}
+
+// CacheConfig is a cache config
+type CacheConfig struct {
+	Name        string `json:"name,omitempty"`
+	Class       string `json:"class,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	InitialSize int    `json:"initialSize,omitempty"`
+	// AutowarmCount is either a number or a percentage e.g. "10%"
+	AutowarmCount string `json:"autowarmCount,omitempty"`
+	MaxRAMMB      int    `json:"maxRamMB,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Solr returns
+// the cache attributes as strings e.g. {"size":"512"}.
+func (c *CacheConfig) UnmarshalJSON(b []byte) error {
+	var m map[string]interface{}
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+
+	ints := map[string]*int{
+		"size":        &c.Size,
+		"initialSize": &c.InitialSize,
+		"maxRamMB":    &c.MaxRAMMB,
+	}
+	for k, v := range m {
+		s := fmt.Sprint(v)
+		switch k {
+		case "name":
+			c.Name = s
+		case "class":
+			c.Class = s
+		case "autowarmCount":
+			c.AutowarmCount = s
+		default:
+			p, ok := ints[k]
+			if !ok {
+				continue
+			}
+
+			*p, err = strconv.Atoi(s)
+			if err != nil {
+				return fmt.Errorf("invalid cache %s: %w", k, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RequestHandlerConfig is a request handler config
+type RequestHandlerConfig struct {
+	Name            string   `json:"name"`
+	Class           string   `json:"class"`
+	Startup         string   `json:"startup,omitempty"`
+	UseParams       string   `json:"useParams,omitempty"`
+	Defaults        M        `json:"defaults,omitempty"`
+	Appends         M        `json:"appends,omitempty"`
+	Invariants      M        `json:"invariants,omitempty"`
+	Components      []string `json:"components,omitempty"`
+	FirstComponents []string `json:"first-components,omitempty"`
+	LastComponents  []string `json:"last-components,omitempty"`
+}
+
+// ConfigOverlayResponse is the config overlay response
+type ConfigOverlayResponse struct {
+	*BaseResponse
+	Overlay *ConfigOverlay `json:"overlay"`
+}
+
+// ConfigOverlay is the configoverlay.json i.e. the changes made via the Config API
+type ConfigOverlay struct {
+	ZNodeVersion int `json:"znodeVersion"`
+	// Props is the properties set via SetProperties
+	Props M `json:"props,omitempty"`
+	// UserProps is the user-defined properties
+	UserProps       M                                `json:"userProps,omitempty"`
+	RequestHandlers map[string]*RequestHandlerConfig `json:"requestHandler,omitempty"`
+	// Raw is the whole overlay including the other components
+	Raw M `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (o *ConfigOverlay) UnmarshalJSON(b []byte) error {
+	type configOverlay ConfigOverlay
+	err := json.Unmarshal(b, (*configOverlay)(o))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, &o.Raw)
+}
+
+// Property returns the value of a property set via SetProperties
+// e.g. "updateHandler.autoCommit.maxTime"
+func (o *ConfigOverlay) Property(name string) (interface{}, bool) {
+	var v interface{} = map[string]interface{}(o.Props)
+	for _, key := range strings.Split(name, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		v, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return v, true
+}
@@ -1,9 +1,11 @@
 package solr_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/stevenferrer/solr-go"
 )
@@ -12,3 +14,23 @@ func TestResponseError(t *testing.T) {
 	err := solr.ResponseError{Msg: "an error"}
 	assert.Equal(t, "an error", err.Error())
 }
+
+func TestCommandError(t *testing.T) {
+	b := []byte(`{"add-field":{"name":"id"},"errorMessages":["Field 'id' already exists."]}`)
+
+	var cmdErr solr.CommandError
+	err := json.Unmarshal(b, &cmdErr)
+	require.NoError(t, err)
+
+	assert.Equal(t, "add-field", cmdErr.Command)
+	assert.Equal(t, map[string]interface{}{"name": "id"}, cmdErr.Body)
+	assert.Equal(t, []string{"Field 'id' already exists."}, cmdErr.Messages)
+	assert.Equal(t, "add-field: Field 'id' already exists.", cmdErr.Error())
+
+	got, err := json.Marshal(cmdErr)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(got))
+
+	err = json.Unmarshal([]byte(`{"errorMessages":"invalid"}`), &cmdErr)
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package solr
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryingRequestSender is a request sender that retries failed requests
+// using exponential backoff with jitter. Connection errors, 429 and 5xx
+// responses are retried. Non-idempotent requests (e.g. POST to /update)
+// are only retried when explicitly enabled.
+type RetryingRequestSender struct {
+	// reqSender is the underlying request sender
+	reqSender RequestSender
+	// maxRetries is the maximum number of retries
+	maxRetries int
+	// minBackoff is the backoff before the first retry
+	minBackoff time.Duration
+	// maxBackoff is the maximum backoff between retries
+	maxBackoff time.Duration
+	// retryNonIdempotent enables retrying non-idempotent requests
+	retryNonIdempotent bool
+}
+
+var _ RequestSender = (*RetryingRequestSender)(nil)
+
+// NewRetryingRequestSender takes the underlying request sender
+// and returns a new RetryingRequestSender
+func NewRetryingRequestSender(reqSender RequestSender) *RetryingRequestSender {
+	return &RetryingRequestSender{
+		reqSender:  reqSender,
+		maxRetries: 3,
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 10 * time.Second,
+	}
+}
+
+// WithMaxRetries sets the maximum number of retries
+func (rs *RetryingRequestSender) WithMaxRetries(maxRetries int) *RetryingRequestSender {
+	rs.maxRetries = maxRetries
+	return rs
+}
+
+// WithBackoff sets the minimum and maximum backoff between retries
+func (rs *RetryingRequestSender) WithBackoff(minBackoff, maxBackoff time.Duration) *RetryingRequestSender {
+	rs.minBackoff = minBackoff
+	rs.maxBackoff = maxBackoff
+	return rs
+}
+
+// WithRetryNonIdempotent enables retrying non-idempotent requests e.g. updates.
+// Only enable this if re-sending the same update is safe for your application.
+func (rs *RetryingRequestSender) WithRetryNonIdempotent() *RetryingRequestSender {
+	rs.retryNonIdempotent = true
+	return rs
+}
+
+// SendRequest sends the request and retries it on failures
+func (rs *RetryingRequestSender) SendRequest(ctx context.Context, httpMethod,
+	urlStr, contentType string, body io.Reader) (*http.Response, error) {
+	newBody, err := bufferBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	retryable := rs.retryNonIdempotent || isIdempotent(httpMethod, urlStr)
+	for attempt := 0; ; attempt++ {
+		httpResp, err := rs.reqSender.SendRequest(ctx, httpMethod,
+			urlStr, contentType, newBody())
+		if !retryable || attempt >= rs.maxRetries ||
+			!shouldRetry(ctx, httpResp, err) {
+			return httpResp, err
+		}
+
+		wait := rs.backoff(attempt)
+		if retryAfter, ok := parseRetryAfter(httpResp); ok {
+			wait = retryAfter
+		}
+
+		// give up early if we can't retry before the deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return httpResp, err
+		}
+
+		if httpResp != nil {
+			drainAndClose(httpResp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, wrapErr(ctx.Err(), "wait for retry")
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the exponential backoff with jitter for the given attempt
+func (rs *RetryingRequestSender) backoff(attempt int) time.Duration {
+	return exponentialBackoff(rs.minBackoff, rs.maxBackoff, attempt)
+}
+
+// exponentialBackoff returns the exponential backoff with jitter for the given attempt
+func exponentialBackoff(minBackoff, maxBackoff time.Duration, attempt int) time.Duration {
+	backoff := maxBackoff
+	if attempt < 32 {
+		if d := minBackoff << attempt; d > 0 && d < maxBackoff {
+			backoff = d
+		}
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	// randomize between half and the full backoff
+	half := int64(backoff / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// bufferBody buffers the request body so that it can be re-sent on retries
+func bufferBody(body io.Reader) (func() io.Reader, error) {
+	if body == nil {
+		return func() io.Reader { return nil }, nil
+	}
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, wrapErr(err, "buffer request body")
+	}
+
+	return func() io.Reader { return bytes.NewReader(b) }, nil
+}
+
+// isIdempotent returns true if the request can be safely re-sent
+func isIdempotent(httpMethod, urlStr string) bool {
+	switch httpMethod {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		// the JSON request API uses POST but it's read-only
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			return false
+		}
+
+		return strings.HasSuffix(u.Path, "/query") ||
+			strings.HasSuffix(u.Path, "/select")
+	}
+
+	return false
+}
+
+// shouldRetry returns true if the request failed with a retryable error
+func shouldRetry(ctx context.Context, httpResp *http.Response, err error) bool {
+	if err != nil {
+		// don't retry if the caller gave up
+		return ctx.Err() == nil
+	}
+
+	return httpResp.StatusCode == http.StatusTooManyRequests ||
+		httpResp.StatusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses the Retry-After header which can either be
+// a number of seconds or an HTTP date
+func parseRetryAfter(httpResp *http.Response) (time.Duration, bool) {
+	if httpResp == nil {
+		return 0, false
+	}
+
+	retryAfter := httpResp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(retryAfter); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// drainAndClose discards the rest of the response body and closes it
+// so that the underlying connection can be re-used
+func drainAndClose(httpResp *http.Response) {
+	if httpResp.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, httpResp.Body)
+	_ = httpResp.Body.Close()
+}
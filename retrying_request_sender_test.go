@@ -0,0 +1,201 @@
+package solr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// fakeRequestSender is a request sender that replies with the given responses
+type fakeRequestSender struct {
+	statusCodes []int
+	headers     []http.Header
+	bodies      []string
+	err         error
+	calls       int
+}
+
+var _ solr.RequestSender = (*fakeRequestSender)(nil)
+
+func (rs *fakeRequestSender) SendRequest(_ context.Context, _, _, _ string, body io.Reader) (*http.Response, error) {
+	i := rs.calls
+	rs.calls++
+
+	if body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		rs.bodies = append(rs.bodies, string(b))
+	}
+
+	if rs.err != nil {
+		return nil, rs.err
+	}
+
+	resp := httptest.NewRecorder()
+	if i < len(rs.headers) {
+		for k, v := range rs.headers[i] {
+			resp.Header()[k] = v
+		}
+	}
+	resp.WriteHeader(rs.statusCodes[i])
+
+	return resp.Result(), nil
+}
+
+func TestRetryingRequestSender(t *testing.T) {
+	ctx := context.Background()
+	baseURL := "https://solr.example.com"
+
+	t.Run("retries 5xx and resends body", func(t *testing.T) {
+		fake := &fakeRequestSender{statusCodes: []int{
+			http.StatusServiceUnavailable,
+			http.StatusInternalServerError,
+			http.StatusOK,
+		}}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithBackoff(time.Millisecond, 5*time.Millisecond)
+
+		resp, err := rs.SendRequest(ctx, http.MethodPost,
+			baseURL+"/solr/products/query", solr.JSON.String(),
+			strings.NewReader(`{"query":"*:*"}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, fake.calls)
+		assert.Equal(t, []string{`{"query":"*:*"}`, `{"query":"*:*"}`, `{"query":"*:*"}`}, fake.bodies)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		fake := &fakeRequestSender{statusCodes: []int{
+			http.StatusBadGateway,
+			http.StatusBadGateway,
+			http.StatusBadGateway,
+		}}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithMaxRetries(2).
+			WithBackoff(time.Millisecond, 5*time.Millisecond)
+
+		resp, err := rs.SendRequest(ctx, http.MethodGet,
+			baseURL+"/solr/admin/cores", solr.JSON.String(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, 3, fake.calls)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		fake := &fakeRequestSender{statusCodes: []int{http.StatusBadRequest}}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithBackoff(time.Millisecond, 5*time.Millisecond)
+
+		resp, err := rs.SendRequest(ctx, http.MethodGet,
+			baseURL+"/solr/admin/cores", solr.JSON.String(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("retries connection errors", func(t *testing.T) {
+		errConn := errors.New("connection refused")
+		fake := &fakeRequestSender{err: errConn}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithMaxRetries(2).
+			WithBackoff(time.Millisecond, 5*time.Millisecond)
+
+		_, err := rs.SendRequest(ctx, http.MethodGet,
+			baseURL+"/solr/admin/cores", solr.JSON.String(), nil)
+		assert.ErrorIs(t, err, errConn)
+		assert.Equal(t, 3, fake.calls)
+	})
+
+	t.Run("non-idempotent requests", func(t *testing.T) {
+		fake := &fakeRequestSender{statusCodes: []int{
+			http.StatusServiceUnavailable,
+			http.StatusOK,
+		}}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithBackoff(time.Millisecond, 5*time.Millisecond)
+
+		resp, err := rs.SendRequest(ctx, http.MethodPost,
+			baseURL+"/solr/products/update", solr.JSON.String(),
+			strings.NewReader(`[{"id":1}]`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 1, fake.calls)
+
+		fake = &fakeRequestSender{statusCodes: []int{
+			http.StatusServiceUnavailable,
+			http.StatusOK,
+		}}
+		rs = solr.NewRetryingRequestSender(fake).
+			WithBackoff(time.Millisecond, 5*time.Millisecond).
+			WithRetryNonIdempotent()
+
+		resp, err = rs.SendRequest(ctx, http.MethodPost,
+			baseURL+"/solr/products/update", solr.JSON.String(),
+			strings.NewReader(`[{"id":1}]`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{`[{"id":1}]`, `[{"id":1}]`}, fake.bodies)
+	})
+
+	t.Run("honours retry-after", func(t *testing.T) {
+		fake := &fakeRequestSender{
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusOK},
+			headers:     []http.Header{{"Retry-After": []string{"1"}}},
+		}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithBackoff(time.Millisecond, 5*time.Millisecond)
+
+		start := time.Now()
+		resp, err := rs.SendRequest(ctx, http.MethodGet,
+			baseURL+"/solr/admin/cores", solr.JSON.String(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("respects context deadline", func(t *testing.T) {
+		fake := &fakeRequestSender{
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusOK},
+			headers:     []http.Header{{"Retry-After": []string{"60"}}},
+		}
+		rs := solr.NewRetryingRequestSender(fake)
+
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		resp, err := rs.SendRequest(ctx, http.MethodGet,
+			baseURL+"/solr/admin/cores", solr.JSON.String(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("context cancelled while waiting", func(t *testing.T) {
+		fake := &fakeRequestSender{statusCodes: []int{
+			http.StatusServiceUnavailable,
+			http.StatusOK,
+		}}
+		rs := solr.NewRetryingRequestSender(fake).
+			WithBackoff(time.Minute, time.Minute)
+
+		ctx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := rs.SendRequest(ctx, http.MethodGet,
+			baseURL+"/solr/admin/cores", solr.JSON.String(), nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, fake.calls)
+	})
+}
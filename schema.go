@@ -1,16 +1,56 @@
 package solr
 
+import "net/url"
+
 // Schema is a schema
 type Schema struct {
-	Name       string      `json:"name"`
-	Version    float64     `json:"version"`
-	UniqueKey  string      `json:"uniqueKey"`
-	FieldTypes []FieldType `json:"fieldTypes,omitempty"`
-	Fields     []Field     `json:"fields,omitempty"`
-	CopyFields []CopyField `json:"copyFields"`
+	Name          string      `json:"name"`
+	Version       float64     `json:"version"`
+	UniqueKey     string      `json:"uniqueKey"`
+	FieldTypes    []FieldType `json:"fieldTypes,omitempty"`
+	Fields        []Field     `json:"fields,omitempty"`
+	DynamicFields []Field     `json:"dynamicFields,omitempty"`
+	CopyFields    []CopyField `json:"copyFields"`
+	// Similarity is the similarity class and its params
+	Similarity M `json:"similarity,omitempty"`
+}
+
+// SchemaCommand is a Schema API command
+type SchemaCommand struct {
+	// Name is the command name e.g. "add-field"
+	Name string
+	// Body is the command body e.g. a Field
+	Body interface{}
+}
+
+// SchemaOptions is the options for reading fields and field types
+type SchemaOptions struct {
+	showDefaults bool
+}
+
+// NewSchemaOptions returns a new SchemaOptions
+func NewSchemaOptions() *SchemaOptions {
+	return &SchemaOptions{}
+}
+
+// ShowDefaults includes the properties inherited from the field type
+func (o *SchemaOptions) ShowDefaults() *SchemaOptions {
+	o.showDefaults = true
+	return o
 }
 
-// FieldType is a field type
+// BuildParams builds the options
+func (o *SchemaOptions) BuildParams() string {
+	vals := url.Values{}
+	if o.showDefaults {
+		vals.Set("showDefaults", "true")
+	}
+
+	return vals.Encode()
+}
+
+// FieldType is a field type. The bool properties are pointers so that false can be sent,
+// use Bool to set them or build the field type via NewFieldTypeBuilder.
 type FieldType struct {
 	Name                      string    `json:"name"`
 	Class                     string    `json:"class,omitempty"`
@@ -20,23 +60,23 @@ type FieldType struct {
 	EnableGraphQueries        string    `json:"enableGraphQueries,omitempty"`
 	DocValuesFormat           string    `json:"docValuesFormat,omitempty"`
 	PostingsFormat            string    `json:"postingsFormat,omitempty"`
-	Indexed                   bool      `json:"indexed,omitempty"`
-	Stored                    bool      `json:"stored,omitempty"`
-	DocValues                 bool      `json:"docValues,omitempty"`
-	SortMissingFirst          bool      `json:"sortMissingFirst,omitempty"`
-	SortMissingLast           bool      `json:"sortMissingLast,omitempty"`
-	MultiValued               bool      `json:"multiValued,omitempty"`
-	Uninvertible              bool      `json:"uninvertible,omitempty"`
-	OmitNorms                 bool      `json:"omitNorms,omitempty"`
-	OmitTermFreqAndPositions  bool      `json:"omitTermFreqAndPositions,omitempty"`
-	OmitPositions             bool      `json:"omitPositions,omitempty"`
-	TermVectors               bool      `json:"termVectors,omitempty"`
-	TermPositions             bool      `json:"termPositions,omitempty"`
-	TermOffsets               bool      `json:"termOffsets,omitempty"`
-	TermPayloads              bool      `json:"termPayloads,omitempty"`
-	Required                  bool      `json:"required,omitempty"`
-	UseDocValuesAsStored      bool      `json:"useDocValuesAsStored,omitempty"`
-	Large                     bool      `json:"large,omitempty"`
+	Indexed                   *bool     `json:"indexed,omitempty"`
+	Stored                    *bool     `json:"stored,omitempty"`
+	DocValues                 *bool     `json:"docValues,omitempty"`
+	SortMissingFirst          *bool     `json:"sortMissingFirst,omitempty"`
+	SortMissingLast           *bool     `json:"sortMissingLast,omitempty"`
+	MultiValued               *bool     `json:"multiValued,omitempty"`
+	Uninvertible              *bool     `json:"uninvertible,omitempty"`
+	OmitNorms                 *bool     `json:"omitNorms,omitempty"`
+	OmitTermFreqAndPositions  *bool     `json:"omitTermFreqAndPositions,omitempty"`
+	OmitPositions             *bool     `json:"omitPositions,omitempty"`
+	TermVectors               *bool     `json:"termVectors,omitempty"`
+	TermPositions             *bool     `json:"termPositions,omitempty"`
+	TermOffsets               *bool     `json:"termOffsets,omitempty"`
+	TermPayloads              *bool     `json:"termPayloads,omitempty"`
+	Required                  *bool     `json:"required,omitempty"`
+	UseDocValuesAsStored      *bool     `json:"useDocValuesAsStored,omitempty"`
+	Large                     *bool     `json:"large,omitempty"`
 	MaxCharsForDocValues      string    `json:"maxCharsForDocValues,omitempty"`
 	Geo                       string    `json:"geo,omitempty"`
 	MaxDistErr                string    `json:"maxDistErr,omitempty"`
@@ -49,67 +89,33 @@ type FieldType struct {
 	QueryAnalyzer             *Analyzer `json:"queryAnalyzer,omitempty"`
 }
 
-// Tokenizer is a tokenizer
-type Tokenizer struct {
-	Class                 string `json:"class"`
-	Delimeter             string `json:"delimiter,omitempty"`
-	OutputUnknownUnigrams string `json:"outputUnknownUnigrams,omitempty"`
-	DecompoundMode        string `json:"decompoundMode,omitempty"`
-	Mode                  string `json:"mode,omitempty"`
-}
-
-// Analyzer is an analyzer
-type Analyzer struct {
-	Tokenizer   *Tokenizer `json:"tokenizer"`
-	Filters     []Filter   `json:"filters,omitempty"`
-	CharFilters []Filter   `json:"charFilters,omitempty"`
-}
-
-// Filter is a filter
-type Filter struct {
-	Class               string `json:"class"`
-	Encoder             string `json:"encoder,omitempty"`
-	Inject              string `json:"inject,omitempty"`
-	Words               string `json:"words,omitempty"`
-	IgnoreCase          string `json:"ignoreCase,omitempty"`
-	Articles            string `json:"articles,omitempty"`
-	Language            string `json:"language,omitempty"`
-	Format              string `json:"format,omitempty"`
-	Protected           string `json:"protected,omitempty"`
-	Expand              string `json:"expand,omitempty"`
-	Synonyms            string `json:"synonyms,omitempty"`
-	CatenateNumbers     string `json:"catenateNumbers,omitempty"`
-	GenerateNumberParts string `json:"generateNumberParts,omitempty"`
-	SplitOnCaseChange   string `json:"splitOnCaseChange,omitempty"`
-	GenerateWordParts   string `json:"generateWordParts,omitempty"`
-	CatenateAll         string `json:"catenateAll,omitempty"`
-	CatenateWords       string `json:"catenateWords,omitempty"`
-	MaxPosQuestion      string `json:"maxPosQuestion,omitempty"`
-	MaxFractionAsterisk string `json:"maxFractionAsterisk,omitempty"`
-	MaxPosAsterisk      string `json:"maxPosAsterisk,omitempty"`
-	WithOriginal        string `json:"withOriginal,omitempty"`
-	StemDerivational    string `json:"stemDerivational,omitempty"`
-	MinimumLength       string `json:"minimumLength,omitempty"`
-	Dictionary          string `json:"dictionary,omitempty"`
-	Tags                string `json:"tags,omitempty"`
-	Replacement         string `json:"replacement,omitempty"`
-	Pattern             string `json:"pattern,omitempty"`
-	PreserveOriginal    string `json:"preserveOriginal,omitempty"`
-	Replace             string `json:"replace,omitempty"`
-	MinGramSize         int    `json:"minGramSize,omitempty"`
-	MaxGramSize         int    `json:"maxGramSize,omitempty"`
-}
-
-// Field is a field
+// Field is a field or a dynamic field. The bool properties are pointers so that false can be
+// sent to override the field type defaults, use Bool to set them e.g. Stored: solr.Bool(false)
+// or build the field via NewFieldBuilder.
+//
+// Breaking change: the bool properties used to be plain bools, literals like
+// Field{Indexed: true} must be changed to Field{Indexed: solr.Bool(true)}.
 type Field struct {
-	Name                 string `json:"name"`
-	Type                 string `json:"type,omitempty"`
-	DocValues            bool   `json:"docValues,omitempty"`
-	Indexed              bool   `json:"indexed,omitempty"`
-	Stored               bool   `json:"stored,omitempty"`
-	MultiValued          bool   `json:"multiValued,omitempty"`
-	Required             bool   `json:"required,omitempty"`
-	UseDocValuesAsStored bool   `json:"useDocValuesAsStored,omitempty"`
+	Name                     string `json:"name"`
+	Type                     string `json:"type,omitempty"`
+	Default                  string `json:"default,omitempty"`
+	DocValues                *bool  `json:"docValues,omitempty"`
+	Indexed                  *bool  `json:"indexed,omitempty"`
+	Stored                   *bool  `json:"stored,omitempty"`
+	MultiValued              *bool  `json:"multiValued,omitempty"`
+	Required                 *bool  `json:"required,omitempty"`
+	UseDocValuesAsStored     *bool  `json:"useDocValuesAsStored,omitempty"`
+	SortMissingFirst         *bool  `json:"sortMissingFirst,omitempty"`
+	SortMissingLast          *bool  `json:"sortMissingLast,omitempty"`
+	Uninvertible             *bool  `json:"uninvertible,omitempty"`
+	OmitNorms                *bool  `json:"omitNorms,omitempty"`
+	OmitTermFreqAndPositions *bool  `json:"omitTermFreqAndPositions,omitempty"`
+	OmitPositions            *bool  `json:"omitPositions,omitempty"`
+	TermVectors              *bool  `json:"termVectors,omitempty"`
+	TermPositions            *bool  `json:"termPositions,omitempty"`
+	TermOffsets              *bool  `json:"termOffsets,omitempty"`
+	TermPayloads             *bool  `json:"termPayloads,omitempty"`
+	Large                    *bool  `json:"large,omitempty"`
 }
 
 // CopyField is a copy field
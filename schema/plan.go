@@ -0,0 +1,235 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// Plan is the ordered list of Schema API commands that migrates the live schema to the desired schema
+type Plan struct {
+	Commands []solr.SchemaCommand
+}
+
+// Empty returns true if the live schema is already up to date
+func (p *Plan) Empty() bool {
+	return len(p.Commands) == 0
+}
+
+// String returns the plan with one command per line e.g.
+//
+//	add-field {"name":"title","type":"text_general"}
+func (p *Plan) String() string {
+	sb := &strings.Builder{}
+	for _, command := range p.Commands {
+		b, err := json.Marshal(command.Body)
+		if err != nil {
+			b = []byte(err.Error())
+		}
+
+		sb.WriteString(command.Name)
+		sb.WriteByte(' ')
+		sb.Write(b)
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// Diff compares the live schema against the desired schema and returns the plan. The commands are ordered
+// so that the dependencies exist when they're needed:
+//
+//  1. delete-copy-field
+//  2. add-field-type, replace-field-type
+//  3. add-field, replace-field, add-dynamic-field, replace-dynamic-field
+//  4. delete-field, delete-dynamic-field
+//  5. delete-field-type
+//  6. add-copy-field
+//
+// The deletes are only planned if pruning is enabled, except for the changed copy fields.
+func Diff(live, desired *solr.Schema, opts *Options) *Plan {
+	if opts == nil {
+		opts = NewOptions()
+	}
+
+	var (
+		plan = &Plan{}
+		add  = func(name string, body interface{}) {
+			plan.Commands = append(plan.Commands, solr.SchemaCommand{Name: name, Body: body})
+		}
+	)
+
+	liveCopyFields := map[solr.CopyField]bool{}
+	for _, cf := range live.CopyFields {
+		liveCopyFields[cf] = true
+	}
+
+	desiredCopyFields := map[solr.CopyField]bool{}
+	// desiredPairs is the source and dest of the desired copy fields
+	desiredPairs := map[solr.CopyField]bool{}
+	for _, cf := range desired.CopyFields {
+		desiredCopyFields[cf] = true
+		desiredPairs[solr.CopyField{Source: cf.Source, Dest: cf.Dest}] = true
+	}
+
+	// 1. copy fields don't have a replace command, changed copy fields i.e. the same source
+	// and dest with a different maxChars are deleted then added even if pruning is disabled
+	for _, cf := range live.CopyFields {
+		if desiredCopyFields[cf] {
+			continue
+		}
+
+		pair := solr.CopyField{Source: cf.Source, Dest: cf.Dest}
+		if opts.prune || desiredPairs[pair] {
+			add("delete-copy-field", pair)
+		}
+	}
+
+	// 2. field types
+	liveFieldTypes := map[string]solr.FieldType{}
+	for _, ft := range live.FieldTypes {
+		liveFieldTypes[ft.Name] = ft
+	}
+
+	for _, ft := range desired.FieldTypes {
+		liveFt, ok := liveFieldTypes[ft.Name]
+		if !ok {
+			add("add-field-type", ft)
+		} else if !equalFieldTypes(liveFt, ft) {
+			add("replace-field-type", ft)
+		}
+	}
+
+	// 3. fields and dynamic fields
+	diffFields(live.Fields, desired.Fields, "field", add)
+	diffFields(live.DynamicFields, desired.DynamicFields, "dynamic-field", add)
+
+	if opts.prune {
+		// 4. fields and dynamic fields that are no longer used
+		pruneFields(live.Fields, desired.Fields, live.UniqueKey, "delete-field", add)
+		pruneFields(live.DynamicFields, desired.DynamicFields, "", "delete-dynamic-field", add)
+
+		// 5. field types that are no longer used
+		desiredFieldTypes := map[string]bool{}
+		for _, ft := range desired.FieldTypes {
+			desiredFieldTypes[ft.Name] = true
+		}
+
+		for _, ft := range live.FieldTypes {
+			if !desiredFieldTypes[ft.Name] && !fieldTypeInUse(ft.Name, live, desired) {
+				add("delete-field-type", solr.M{"name": ft.Name})
+			}
+		}
+	}
+
+	// 6. copy fields, after the source and dest fields exist
+	for _, cf := range desired.CopyFields {
+		if !liveCopyFields[cf] {
+			add("add-copy-field", cf)
+		}
+	}
+
+	return plan
+}
+
+// equalFieldTypes compares the field types by their JSON encoding. The scalars are compared
+// as strings since the schema API returns the analyzer params as strings e.g. {"minGramSize":"2"}.
+func equalFieldTypes(a, b solr.FieldType) bool {
+	return reflect.DeepEqual(normalizeJSON(a), normalizeJSON(b))
+}
+
+// normalizeJSON encodes v to JSON then decodes it with the scalars converted to strings
+func normalizeJSON(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+
+	var decoded interface{}
+	_ = json.Unmarshal(b, &decoded)
+	return stringifyScalars(decoded)
+}
+
+func stringifyScalars(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = stringifyScalars(val)
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = stringifyScalars(val)
+		}
+		return v
+	case nil:
+		return nil
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// diffFields plans the add and replace commands of the fields
+func diffFields(live, desired []solr.Field, kind string, add func(string, interface{})) {
+	liveFields := map[string]solr.Field{}
+	for _, f := range live {
+		liveFields[f.Name] = f
+	}
+
+	for _, f := range desired {
+		liveField, ok := liveFields[f.Name]
+		if !ok {
+			add("add-"+kind, f)
+		} else if !reflect.DeepEqual(liveField, f) {
+			add("replace-"+kind, f)
+		}
+	}
+}
+
+// pruneFields plans the delete commands of the fields that are not in the desired schema.
+// The unique key and the fields starting with an underscore e.g. _version_ are never deleted.
+func pruneFields(live, desired []solr.Field, uniqueKey, command string, add func(string, interface{})) {
+	desiredFields := map[string]bool{}
+	for _, f := range desired {
+		desiredFields[f.Name] = true
+	}
+
+	for _, f := range live {
+		if desiredFields[f.Name] || f.Name == uniqueKey || strings.HasPrefix(f.Name, "_") {
+			continue
+		}
+
+		add(command, solr.M{"name": f.Name})
+	}
+}
+
+// fieldTypeInUse returns true if a field that's kept after the migration uses the field type
+func fieldTypeInUse(name string, live, desired *solr.Schema) bool {
+	desiredFields := map[string]bool{}
+	for _, fields := range [][]solr.Field{desired.Fields, desired.DynamicFields} {
+		for _, f := range fields {
+			if f.Type == name {
+				return true
+			}
+			desiredFields[f.Name] = true
+		}
+	}
+
+	for _, fields := range [][]solr.Field{live.Fields, live.DynamicFields} {
+		for _, f := range fields {
+			if f.Type != name || desiredFields[f.Name] {
+				continue
+			}
+
+			// kept by pruneFields
+			if f.Name == live.UniqueKey || strings.HasPrefix(f.Name, "_") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
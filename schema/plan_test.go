@@ -0,0 +1,165 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+	"github.com/stevenferrer/solr-go/schema"
+)
+
+func liveSchema() *solr.Schema {
+	return &solr.Schema{
+		UniqueKey: "id",
+		FieldTypes: []solr.FieldType{
+			{Name: "string", Class: "solr.StrField", SortMissingLast: solr.Bool(true)},
+			{Name: "text_old", Class: "solr.TextField"},
+			{Name: "pint", Class: "solr.IntPointField"},
+		},
+		Fields: []solr.Field{
+			{Name: "id", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), Required: solr.Bool(true)},
+			{Name: "_version_", Type: "plong", Indexed: solr.Bool(false), Stored: solr.Bool(false)},
+			{Name: "name", Type: "text_old", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+			{Name: "legacy", Type: "string", Stored: solr.Bool(true)},
+		},
+		DynamicFields: []solr.Field{
+			{Name: "*_s", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+			{Name: "*_i", Type: "pint", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+		},
+		CopyFields: []solr.CopyField{
+			{Source: "name", Dest: "_text_"},
+			{Source: "legacy", Dest: "_text_"},
+		},
+	}
+}
+
+func desiredSchema() *solr.Schema {
+	return &solr.Schema{
+		FieldTypes: []solr.FieldType{
+			{Name: "string", Class: "solr.StrField", SortMissingLast: solr.Bool(true)},
+			{Name: "text_new", Class: "solr.TextField", PositionIncrementGap: "100"},
+		},
+		Fields: []solr.Field{
+			{Name: "id", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), Required: solr.Bool(true)},
+			{Name: "name", Type: "text_new", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+			{Name: "title", Type: "text_new", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+		},
+		DynamicFields: []solr.Field{
+			{Name: "*_s", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), DocValues: solr.Bool(true)},
+		},
+		CopyFields: []solr.CopyField{
+			{Source: "name", Dest: "_text_", MaxChars: 256},
+			{Source: "title", Dest: "_text_"},
+		},
+	}
+}
+
+func commandNames(plan *schema.Plan) []string {
+	names := []string{}
+	for _, command := range plan.Commands {
+		names = append(names, command.Name)
+	}
+	return names
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("no prune", func(t *testing.T) {
+		plan := schema.Diff(liveSchema(), desiredSchema(), nil)
+
+		// the changed copy field is replaced, the legacy copy field is kept
+		expect := []solr.SchemaCommand{
+			{Name: "delete-copy-field", Body: solr.CopyField{Source: "name", Dest: "_text_"}},
+			{Name: "add-field-type", Body: solr.FieldType{Name: "text_new", Class: "solr.TextField", PositionIncrementGap: "100"}},
+			{Name: "replace-field", Body: solr.Field{Name: "name", Type: "text_new", Indexed: solr.Bool(true), Stored: solr.Bool(true)}},
+			{Name: "add-field", Body: solr.Field{Name: "title", Type: "text_new", Indexed: solr.Bool(true), Stored: solr.Bool(true)}},
+			{Name: "replace-dynamic-field", Body: solr.Field{Name: "*_s", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), DocValues: solr.Bool(true)}},
+			{Name: "add-copy-field", Body: solr.CopyField{Source: "name", Dest: "_text_", MaxChars: 256}},
+			{Name: "add-copy-field", Body: solr.CopyField{Source: "title", Dest: "_text_"}},
+		}
+		assert.Equal(t, expect, plan.Commands)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		plan := schema.Diff(liveSchema(), desiredSchema(), schema.NewOptions().Prune())
+
+		expect := []string{
+			"delete-copy-field",
+			"delete-copy-field",
+			"add-field-type",
+			"replace-field",
+			"add-field",
+			"replace-dynamic-field",
+			"delete-field",
+			"delete-dynamic-field",
+			"delete-field-type",
+			"delete-field-type",
+			"add-copy-field",
+			"add-copy-field",
+		}
+		assert.Equal(t, expect, commandNames(plan))
+
+		assert.Equal(t, solr.CopyField{Source: "name", Dest: "_text_"}, plan.Commands[0].Body)
+		assert.Equal(t, solr.CopyField{Source: "legacy", Dest: "_text_"}, plan.Commands[1].Body)
+		assert.Equal(t, solr.M{"name": "legacy"}, plan.Commands[6].Body)
+		assert.Equal(t, solr.M{"name": "*_i"}, plan.Commands[7].Body)
+		assert.Equal(t, solr.M{"name": "text_old"}, plan.Commands[8].Body)
+		assert.Equal(t, solr.M{"name": "pint"}, plan.Commands[9].Body)
+	})
+
+	t.Run("field type in use", func(t *testing.T) {
+		live := liveSchema()
+		live.Fields = append(live.Fields, solr.Field{Name: "_root_", Type: "text_old"})
+
+		plan := schema.Diff(live, desiredSchema(), schema.NewOptions().Prune())
+		for _, command := range plan.Commands {
+			assert.NotEqual(t, solr.SchemaCommand{Name: "delete-field-type", Body: solr.M{"name": "text_old"}}, command)
+		}
+	})
+
+	t.Run("analyzer params", func(t *testing.T) {
+		live := liveSchema()
+		live.FieldTypes = append(live.FieldTypes, solr.FieldType{
+			Name:  "text_prefix",
+			Class: "solr.TextField",
+			IndexAnalyzer: solr.NewAnalyzer(solr.NewStandardTokenizer(),
+				solr.NewEdgeNGramFilter(2, 15).With("preserveOriginal", "true")),
+		})
+
+		desired := liveSchema()
+		desired.FieldTypes = append(desired.FieldTypes, solr.FieldType{
+			Name:  "text_prefix",
+			Class: "solr.TextField",
+			IndexAnalyzer: solr.NewAnalyzer(solr.NewStandardTokenizer(),
+				solr.NewAnalyzerComponent("solr.EdgeNGramFilterFactory").
+					With("preserveOriginal", true).
+					With("minGramSize", "2").
+					With("maxGramSize", "15")),
+		})
+
+		// the same params in a different order and encoding
+		assert.True(t, schema.Diff(live, desired, nil).Empty())
+
+		desired.FieldTypes[len(desired.FieldTypes)-1].IndexAnalyzer.Filters[0] =
+			solr.NewEdgeNGramFilter(1, 15)
+		assert.Equal(t, []string{"replace-field-type"}, commandNames(schema.Diff(live, desired, nil)))
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		plan := schema.Diff(desiredSchema(), desiredSchema(), schema.NewOptions().Prune())
+		assert.True(t, plan.Empty())
+		assert.Equal(t, "", plan.String())
+	})
+}
+
+func TestPlanString(t *testing.T) {
+	plan := &schema.Plan{Commands: []solr.SchemaCommand{
+		{Name: "add-field", Body: solr.Field{Name: "title", Type: "text_general"}},
+		{Name: "delete-field", Body: solr.M{"name": "legacy"}},
+	}}
+
+	expect := `add-field {"name":"title","type":"text_general"}
+delete-field {"name":"legacy"}
+`
+	assert.Equal(t, expect, plan.String())
+}
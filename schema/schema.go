@@ -0,0 +1,89 @@
+// Package schema migrates the schema of a collection to a schema declared in Go code.
+//
+// Apply reads the live schema, computes the plan and executes it as a single
+// Schema API request, so the fields that already exist are replaced instead
+// of failing and nothing is applied if one of the commands fails:
+//
+//	desired := &solr.Schema{
+//		Fields: []solr.Field{
+//			{Name: "title", Type: "text_general", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+//		},
+//		CopyFields: []solr.CopyField{
+//			{Source: "title", Dest: "_text_"},
+//		},
+//	}
+//
+//	plan, err := schema.Apply(ctx, client, "products", desired, schema.NewOptions().DryRun())
+//	...
+//	fmt.Print(plan)
+//
+// The unique key and the similarity can't be changed via the Schema API and are not migrated.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// Options is the Apply options
+type Options struct {
+	dryRun bool
+	prune  bool
+}
+
+// NewOptions returns a new Options
+func NewOptions() *Options {
+	return &Options{}
+}
+
+// DryRun only computes the plan without executing it
+func (o *Options) DryRun() *Options {
+	o.dryRun = true
+	return o
+}
+
+// Prune deletes the field types, fields, dynamic fields and copy fields that are not in the desired schema.
+// The unique key and the fields starting with an underscore e.g. _version_ and _root_ are never deleted.
+// A copy field whose maxChars changed is replaced, i.e. deleted then added, with or without pruning.
+func (o *Options) Prune() *Options {
+	o.prune = true
+	return o
+}
+
+// Apply migrates the schema of the collection to the desired schema and returns the executed plan.
+// If the plan is empty, no request is sent.
+func Apply(ctx context.Context, client solr.Client, collection string, desired *solr.Schema, opts *Options) (*Plan, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+
+	live, err := client.GetSchema(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("get schema: %w", err)
+	}
+
+	if live == nil {
+		return nil, errors.New("get schema: empty response")
+	}
+
+	if desired.UniqueKey != "" && desired.UniqueKey != live.UniqueKey {
+		return nil, fmt.Errorf("unique key can't be changed from %q to %q", live.UniqueKey, desired.UniqueKey)
+	}
+
+	plan := Diff(live, desired, opts)
+	if opts.dryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	err = client.ModifySchema(ctx, collection, plan.Commands...)
+	if err != nil {
+		return nil, fmt.Errorf("modify schema: %w", err)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,127 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+	"github.com/stevenferrer/solr-go/schema"
+)
+
+// newSchemaServer returns a server that responds with the live schema
+// and records the body of the modify schema requests
+func newSchemaServer(t *testing.T, live *solr.Schema, posts *int32, body *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/solr/products/schema", r.URL.Path)
+		w.Header().Set("content-type", "application/json")
+
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(solr.M{"schema": live})
+			return
+		}
+
+		atomic.AddInt32(posts, 1)
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*body = string(b)
+		_, _ = w.Write([]byte(`{"responseHeader":{"status":0,"QTime":10}}`))
+	}))
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+
+	desired := &solr.Schema{
+		Fields: []solr.Field{
+			{Name: "id", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), Required: solr.Bool(true)},
+			{Name: "title", Type: "string", Stored: solr.Bool(true)},
+		},
+		CopyFields: []solr.CopyField{
+			{Source: "title", Dest: "_text_"},
+		},
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		var (
+			posts int32
+			body  string
+		)
+		ts := newSchemaServer(t, liveSchema(), &posts, &body)
+		defer ts.Close()
+
+		plan, err := schema.Apply(ctx, solr.NewJSONClient(ts.URL), "products", desired, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"add-field", "add-copy-field"}, commandNames(plan))
+
+		assert.Equal(t, int32(1), posts)
+		assert.JSONEq(t, `{
+			"add-field":{"name":"title","type":"string","stored":true},
+			"add-copy-field":{"source":"title","dest":"_text_"}
+		}`, body)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		var posts int32
+		ts := newSchemaServer(t, liveSchema(), &posts, new(string))
+		defer ts.Close()
+
+		plan, err := schema.Apply(ctx, solr.NewJSONClient(ts.URL), "products",
+			desired, schema.NewOptions().DryRun().Prune())
+		require.NoError(t, err)
+		assert.Contains(t, commandNames(plan), "delete-field")
+		assert.Equal(t, int32(0), posts)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		var posts int32
+		ts := newSchemaServer(t, desired, &posts, new(string))
+		defer ts.Close()
+
+		plan, err := schema.Apply(ctx, solr.NewJSONClient(ts.URL), "products", desired, nil)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty())
+		assert.Equal(t, int32(0), posts)
+	})
+
+	t.Run("unique key", func(t *testing.T) {
+		var posts int32
+		ts := newSchemaServer(t, liveSchema(), &posts, new(string))
+		defer ts.Close()
+
+		_, err := schema.Apply(ctx, solr.NewJSONClient(ts.URL), "products",
+			&solr.Schema{UniqueKey: "uuid"}, nil)
+		assert.EqualError(t, err, `unique key can't be changed from "id" to "uuid"`)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			if r.Method == http.MethodGet {
+				_ = json.NewEncoder(w).Encode(solr.M{"schema": liveSchema()})
+				return
+			}
+
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"responseHeader":{"status":400},"error":{"msg":"error processing commands","code":400,
+				"details":[{"add-field":{"name":"title","type":"string"},"errorMessages":["Field 'title' already exists."]}]}}`))
+		}))
+		defer ts.Close()
+
+		_, err := schema.Apply(ctx, solr.NewJSONClient(ts.URL), "products", desired, nil)
+		require.Error(t, err)
+		assert.True(t, solr.IsBadRequest(err))
+
+		var solrErr *solr.SolrError
+		require.ErrorAs(t, err, &solrErr)
+		require.Len(t, solrErr.Err.Details, 1)
+		assert.Equal(t, "add-field", solrErr.Err.Details[0].Command)
+	})
+}
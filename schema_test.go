@@ -0,0 +1,42 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestSchemaOptions(t *testing.T) {
+	assert.Equal(t, "", solr.NewSchemaOptions().BuildParams())
+	assert.Equal(t, "showDefaults=true", solr.NewSchemaOptions().ShowDefaults().BuildParams())
+}
+
+func TestFieldTriStateBools(t *testing.T) {
+	field := solr.Field{
+		Name:        "title",
+		Type:        "text_general",
+		Default:     "untitled",
+		Stored:      solr.Bool(false),
+		Indexed:     solr.Bool(true),
+		OmitNorms:   solr.Bool(true),
+		TermVectors: solr.Bool(false),
+	}
+
+	b, err := json.Marshal(field)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"title","type":"text_general","default":"untitled",
+		"stored":false,"indexed":true,"omitNorms":true,"termVectors":false}`, string(b))
+
+	var decoded solr.Field
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, field, decoded)
+	assert.Nil(t, decoded.DocValues)
+
+	b, err = json.Marshal(solr.FieldType{Name: "string", Class: "solr.StrField", SortMissingLast: solr.Bool(true), DocValues: solr.Bool(false)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"string","class":"solr.StrField","sortMissingLast":true,"docValues":false}`, string(b))
+}
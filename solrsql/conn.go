@@ -0,0 +1,106 @@
+package solrsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+var (
+	errArgsNotSupported = errors.New("solrsql: statement parameters are not supported")
+	errExecNotSupported = errors.New("solrsql: exec is not supported, Solr SQL is read-only")
+	errTxNotSupported   = errors.New("solrsql: transactions are not supported")
+)
+
+// conn is a connection to Solr. It's stateless, each query is a separate request.
+type conn struct {
+	client *solr.JSONClient
+	cfg    *config
+}
+
+var (
+	_ driver.Conn           = (*conn)(nil)
+	_ driver.QueryerContext = (*conn)(nil)
+)
+
+// Prepare returns a prepared statement
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+// Close closes the connection
+func (c *conn) Close() error {
+	return nil
+}
+
+// Begin is not supported
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, errTxNotSupported
+}
+
+// QueryContext sends the statement to the SQL handler
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) > 0 {
+		return nil, errArgsNotSupported
+	}
+
+	params := solr.NewSQLParams(query).
+		AggregationMode(c.cfg.aggregationMode).
+		NumWorkers(c.cfg.numWorkers).
+		IncludeMetadata(true)
+
+	it, err := c.client.SQL(ctx, c.cfg.collection, params)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	r, err := newRows(it)
+	if err != nil {
+		_ = it.Close()
+		return nil, mapError(err)
+	}
+
+	return r, nil
+}
+
+// stmt is a prepared statement
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+var (
+	_ driver.Stmt             = (*stmt)(nil)
+	_ driver.StmtQueryContext = (*stmt)(nil)
+)
+
+// Close closes the statement
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput returns the number of placeholder parameters
+func (s *stmt) NumInput() int {
+	return 0
+}
+
+// Exec is not supported
+func (s *stmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errExecNotSupported
+}
+
+// Query executes the statement
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	if len(args) > 0 {
+		return nil, errArgsNotSupported
+	}
+
+	return s.conn.QueryContext(context.Background(), s.query, nil)
+}
+
+// QueryContext executes the statement
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
@@ -0,0 +1,276 @@
+package solrsql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+	"github.com/stevenferrer/solr-go/solrsql"
+)
+
+// newSQLServer returns a test server that responds with the body and records the request form
+func newSQLServer(t *testing.T, status int, body string, form *http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/solr/products/sql", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		if form != nil {
+			*form = *r
+		}
+
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func dsn(ts *httptest.Server, params string) string {
+	return strings.Replace(ts.URL, "http://", "http://solr:SolrRocks@", 1) + "/products" + params
+}
+
+func TestDriver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("query", func(t *testing.T) {
+		var req http.Request
+		ts := newSQLServer(t, http.StatusOK, `{"result-set":{"docs":[
+			{"isMetadata":true,"fields":["cat_s","count","avg_price","in_stock","tags"],"aliases":{}},
+			{"cat_s":"music","count":1,"avg_price":9.0,"in_stock":false},
+			{"cat_s":"books","count":2,"avg_price":12.5,"in_stock":true,"tags":["a","b"]},
+			{"EOF":true,"RESPONSE_TIME":10}
+		]}}`, &req)
+		defer ts.Close()
+
+		db, err := sql.Open("solr", dsn(ts, "?aggregationMode=map_reduce&numWorkers=2"))
+		require.NoError(t, err)
+		defer db.Close()
+
+		stmt := "SELECT cat_s, count(*) AS count FROM products GROUP BY cat_s"
+		rows, err := db.QueryContext(ctx, stmt)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		assert.Equal(t, stmt, req.PostForm.Get("stmt"))
+		assert.Equal(t, "map_reduce", req.PostForm.Get("aggregationMode"))
+		assert.Equal(t, "2", req.PostForm.Get("numWorkers"))
+		assert.Equal(t, "true", req.PostForm.Get("includeMetadata"))
+		username, password, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "solr", username)
+		assert.Equal(t, "SolrRocks", password)
+
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cat_s", "count", "avg_price", "in_stock", "tags"}, columns)
+
+		columnTypes, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		// the type of a column is the type of its first non-null value
+		var typeNames []string
+		for _, ct := range columnTypes {
+			typeNames = append(typeNames, ct.DatabaseTypeName())
+		}
+		assert.Equal(t, []string{"VARCHAR", "BIGINT", "DOUBLE", "BOOLEAN", "ARRAY"}, typeNames)
+		assert.Equal(t, "int64", columnTypes[1].ScanType().String())
+		assert.Equal(t, "float64", columnTypes[2].ScanType().String())
+		nullable, ok := columnTypes[0].Nullable()
+		assert.True(t, nullable && ok)
+
+		type row struct {
+			cat      string
+			count    int64
+			avgPrice float64
+			inStock  bool
+			tags     sql.NullString
+		}
+
+		var got []row
+		for rows.Next() {
+			var r row
+			err = rows.Scan(&r.cat, &r.count, &r.avgPrice, &r.inStock, &r.tags)
+			require.NoError(t, err)
+			got = append(got, r)
+		}
+		require.NoError(t, rows.Err())
+
+		assert.Equal(t, []row{
+			{"music", 1, 9, false, sql.NullString{}},
+			{"books", 2, 12.5, true, sql.NullString{String: `["a","b"]`, Valid: true}},
+		}, got)
+	})
+
+	t.Run("column types", func(t *testing.T) {
+		ts := newSQLServer(t, http.StatusOK, `{"result-set":{"docs":[
+			{"isMetadata":true,"fields":["price","missing"],"aliases":{}},
+			{"price":1.5},
+			{"price":3},
+			{"EOF":true,"RESPONSE_TIME":1}
+		]}}`, nil)
+		defer ts.Close()
+
+		db, err := sql.Open("solr", dsn(ts, ""))
+		require.NoError(t, err)
+		defer db.Close()
+
+		rows, err := db.QueryContext(ctx, "SELECT price, missing FROM products")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columnTypes, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		assert.Equal(t, "DOUBLE", columnTypes[0].DatabaseTypeName())
+		// the column is null in all the rows
+		assert.Equal(t, "", columnTypes[1].DatabaseTypeName())
+		assert.Equal(t, "interface {}", columnTypes[1].ScanType().String())
+
+		// the integral values of a DOUBLE column are float64
+		var prices []interface{}
+		for rows.Next() {
+			var price, missing interface{}
+			require.NoError(t, rows.Scan(&price, &missing))
+			assert.Nil(t, missing)
+			prices = append(prices, price)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, []interface{}{1.5, float64(3)}, prices)
+	})
+
+	t.Run("empty result set", func(t *testing.T) {
+		ts := newSQLServer(t, http.StatusOK, `{"result-set":{"docs":[
+			{"isMetadata":true,"fields":["id"],"aliases":{}},
+			{"EOF":true,"RESPONSE_TIME":1}
+		]}}`, nil)
+		defer ts.Close()
+
+		db, err := sql.Open("solr", dsn(ts, ""))
+		require.NoError(t, err)
+		defer db.Close()
+
+		rows, err := db.QueryContext(ctx, "SELECT id FROM products")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columnTypes, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		assert.Equal(t, "", columnTypes[0].DatabaseTypeName())
+		assert.False(t, rows.Next())
+		assert.NoError(t, rows.Err())
+	})
+
+	t.Run("exception", func(t *testing.T) {
+		ts := newSQLServer(t, http.StatusOK, `{"result-set":{"docs":[
+			{"EXCEPTION":"Failed to execute sqlQuery","EOF":true,"RESPONSE_TIME":1}
+		]}}`, nil)
+		defer ts.Close()
+
+		db, err := sql.Open("solr", dsn(ts, ""))
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = db.QueryContext(ctx, "SELECT * FROM")
+		var sqlErr *solrsql.Error
+		require.ErrorAs(t, err, &sqlErr)
+		assert.Equal(t, "Failed to execute sqlQuery", sqlErr.Message)
+		assert.Zero(t, sqlErr.StatusCode)
+
+		var streamErr *solr.StreamError
+		assert.ErrorAs(t, err, &streamErr)
+	})
+
+	t.Run("solr error", func(t *testing.T) {
+		ts := newSQLServer(t, http.StatusBadRequest, `{"error":{"msg":"Collection not found","code":400}}`, nil)
+		defer ts.Close()
+
+		db, err := sql.Open("solr", dsn(ts, ""))
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = db.QueryContext(ctx, "SELECT * FROM products")
+		var sqlErr *solrsql.Error
+		require.ErrorAs(t, err, &sqlErr)
+		assert.Equal(t, http.StatusBadRequest, sqlErr.StatusCode)
+		assert.Equal(t, "Collection not found", sqlErr.Message)
+		assert.True(t, solr.IsBadRequest(err))
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		db, err := sql.Open("solr", "http://127.0.0.1:0/products")
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = db.QueryContext(ctx, "SELECT * FROM products")
+		assert.True(t, errors.Is(err, driver.ErrBadConn))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		db, err := sql.Open("solr", "http://localhost:8983/products")
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = db.QueryContext(ctx, "SELECT * FROM products WHERE id = ?", 1)
+		assert.Error(t, err)
+
+		_, err = db.ExecContext(ctx, "DELETE FROM products")
+		assert.Error(t, err)
+
+		_, err = db.BeginTx(ctx, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("connector", func(t *testing.T) {
+		var req http.Request
+		ts := newSQLServer(t, http.StatusOK, `{"result-set":{"docs":[
+			{"isMetadata":true,"fields":["id"],"aliases":{}},
+			{"id":"1"},
+			{"EOF":true}
+		]}}`, &req)
+		defer ts.Close()
+
+		connector, err := solrsql.NewConnector(dsn(ts, ""))
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector.WithRequestSender(solr.NewDefaultRequestSender()))
+		defer db.Close()
+
+		var id string
+		err = db.QueryRowContext(ctx, "SELECT id FROM products LIMIT 1").Scan(&id)
+		require.NoError(t, err)
+		assert.Equal(t, "1", id)
+
+		// the basic auth credentials are not used by the custom request sender
+		_, _, ok := req.BasicAuth()
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid dsn", func(t *testing.T) {
+		dsns := []string{
+			"://",
+			"ftp://localhost:8983/products",
+			"http:///products",
+			"http://localhost:8983",
+			"http://localhost:8983/solr/products",
+			"http://localhost:8983/products?aggregationMode=other",
+			"http://localhost:8983/products?numWorkers=0",
+			"http://localhost:8983/products?unknown=1",
+		}
+
+		for _, dsn := range dsns {
+			_, err := solrsql.NewConnector(dsn)
+			assert.Error(t, err, dsn)
+		}
+
+		_, err := sql.Open("solr", "ftp://localhost:8983/products")
+		assert.Error(t, err)
+
+		_, err = (&solrsql.Driver{}).Open("ftp://localhost:8983/products")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package solrsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// Error is an error returned by Solr when executing a statement
+type Error struct {
+	// StatusCode is the HTTP status code, 0 for the exceptions
+	// returned in the result set e.g. SQL parse errors
+	StatusCode int
+	// Message is the error message
+	Message string
+	// Err is the underlying *solr.SolrError or *solr.StreamError
+	Err error
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return "solrsql: " + e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// badConnError is a connection error, it satisfies driver.ErrBadConn so that
+// database/sql retries the statement on a new connection
+type badConnError struct {
+	err error
+}
+
+func (e *badConnError) Error() string {
+	return "solrsql: " + e.err.Error()
+}
+
+func (e *badConnError) Unwrap() error {
+	return e.err
+}
+
+func (e *badConnError) Is(target error) bool {
+	return target == driver.ErrBadConn
+}
+
+// mapError maps the Solr errors to driver errors
+func mapError(err error) error {
+	var streamErr *solr.StreamError
+	if errors.As(err, &streamErr) {
+		return &Error{Message: streamErr.Message, Err: streamErr}
+	}
+
+	var solrErr *solr.SolrError
+	if errors.As(err, &solrErr) {
+		msg := solrErr.Error()
+		if solrErr.Err != nil && solrErr.Err.Msg != "" {
+			msg = solrErr.Err.Msg
+		}
+
+		return &Error{StatusCode: solrErr.StatusCode, Message: msg, Err: solrErr}
+	}
+
+	// don't retry if the caller gave up
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &badConnError{err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,220 @@
+package solrsql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+// List of column database type names
+const (
+	typeBigInt  = "BIGINT"
+	typeDouble  = "DOUBLE"
+	typeVarchar = "VARCHAR"
+	typeBoolean = "BOOLEAN"
+	typeArray   = "ARRAY"
+)
+
+var scanTypes = map[string]reflect.Type{
+	typeBigInt:  reflect.TypeOf(int64(0)),
+	typeDouble:  reflect.TypeOf(float64(0)),
+	typeVarchar: reflect.TypeOf(""),
+	typeBoolean: reflect.TypeOf(false),
+	typeArray:   reflect.TypeOf(""),
+}
+
+var anyType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// maxTypeRows is the maximum number of rows read ahead to determine the column types
+const maxTypeRows = 100
+
+// rows is the result set of a statement. The tuples are read from the
+// response as the rows are scanned.
+type rows struct {
+	it      *solr.TupleIterator
+	columns []string
+	types   []string
+	// buffered is the tuples read ahead to determine the column types
+	buffered []solr.M
+}
+
+var (
+	_ driver.Rows                           = (*rows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+)
+
+// newRows reads the metadata tuple of the result set and the tuples
+// needed to determine the column types
+func newRows(it *solr.TupleIterator) (*rows, error) {
+	if !it.Next() {
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("solrsql: missing metadata tuple")
+	}
+
+	metadata := it.Tuple()
+	if isMetadata, _ := metadata["isMetadata"].(bool); !isMetadata {
+		return nil, errors.New("solrsql: missing metadata tuple")
+	}
+
+	fields, _ := metadata["fields"].([]interface{})
+	r := &rows{
+		it:      it,
+		columns: make([]string, len(fields)),
+		types:   make([]string, len(fields)),
+	}
+
+	for i, field := range fields {
+		r.columns[i] = fmt.Sprint(field)
+	}
+
+	err := r.readTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// readTypes reads ahead until every column has a non-null value or maxTypeRows is reached.
+// The metadata tuple doesn't include the column types so the type of a column is the type
+// of its first non-null value, the same as the ResultSetMetaData of the SolrJ JDBC driver.
+func (r *rows) readTypes() error {
+	for len(r.buffered) < maxTypeRows && !r.typed() {
+		if !r.it.Next() {
+			return r.it.Err()
+		}
+
+		tuple := r.it.Tuple()
+		r.buffered = append(r.buffered, tuple)
+		for i, column := range r.columns {
+			if r.types[i] == "" {
+				r.types[i] = typeName(tuple[column])
+			}
+		}
+	}
+
+	return nil
+}
+
+// typed returns true if the types of all the columns are known
+func (r *rows) typed() bool {
+	for _, typ := range r.types {
+		if typ == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Columns returns the column names
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+// Close closes the response body
+func (r *rows) Close() error {
+	r.buffered = nil
+	return r.it.Close()
+}
+
+// Next populates dest with the values of the next row
+func (r *rows) Next(dest []driver.Value) error {
+	var tuple solr.M
+	if len(r.buffered) > 0 {
+		tuple, r.buffered = r.buffered[0], r.buffered[1:]
+	} else {
+		if !r.it.Next() {
+			if err := r.it.Err(); err != nil {
+				return mapError(err)
+			}
+			return io.EOF
+		}
+		tuple = r.it.Tuple()
+	}
+
+	for i, column := range r.columns {
+		val, err := convertValue(tuple[column], r.types[i])
+		if err != nil {
+			return fmt.Errorf("solrsql: column %q: %w", column, err)
+		}
+		dest[i] = val
+	}
+
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns the type of the first non-null value of the column
+// within the first rows, an empty string if the column is null in all of them
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index]
+}
+
+// ColumnTypeScanType returns the column scan type, interface{} if the type is unknown
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if t, ok := scanTypes[r.types[index]]; ok {
+		return t
+	}
+
+	return anyType
+}
+
+// ColumnTypeNullable returns true since the field can be missing from a document
+func (r *rows) ColumnTypeNullable(int) (nullable, ok bool) {
+	return true, true
+}
+
+// typeName returns the database type name of the tuple value
+func typeName(val interface{}) string {
+	switch v := val.(type) {
+	case json.Number:
+		if isInteger(v) {
+			return typeBigInt
+		}
+		return typeDouble
+	case string:
+		return typeVarchar
+	case bool:
+		return typeBoolean
+	case []interface{}:
+		return typeArray
+	}
+
+	return ""
+}
+
+// convertValue converts the tuple value into a driver value of the column type,
+// arrays and objects are converted to JSON strings
+func convertValue(val interface{}, typ string) (driver.Value, error) {
+	switch v := val.(type) {
+	case nil, string, bool:
+		return v, nil
+	case json.Number:
+		if typ != typeDouble && isInteger(v) {
+			return v.Int64()
+		}
+		return v.Float64()
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// isInteger returns true if the number doesn't have a fraction or an exponent
+func isInteger(n json.Number) bool {
+	return !strings.ContainsAny(n.String(), ".eE")
+}
@@ -0,0 +1,67 @@
+package solr
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// List of SQL aggregation modes
+const (
+	AggregationModeFacet     = "facet"
+	AggregationModeMapReduce = "map_reduce"
+)
+
+// SQLParams is the SQL handler param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/parallel-sql-interface.html#sql-request-handler
+type SQLParams struct {
+	stmt            string
+	aggregationMode string
+	numWorkers      int
+	includeMetadata bool
+}
+
+// NewSQLParams returns a new SQLParams for the statement
+func NewSQLParams(stmt string) *SQLParams {
+	return &SQLParams{stmt: stmt}
+}
+
+// AggregationMode sets the aggregation mode of GROUP BY and SELECT DISTINCT
+// queries i.e. AggregationModeFacet or AggregationModeMapReduce
+func (p *SQLParams) AggregationMode(aggregationMode string) *SQLParams {
+	p.aggregationMode = aggregationMode
+	return p
+}
+
+// NumWorkers sets the number of workers used by the map_reduce aggregation mode
+func (p *SQLParams) NumWorkers(numWorkers int) *SQLParams {
+	p.numWorkers = numWorkers
+	return p
+}
+
+// IncludeMetadata set to true to return a metadata tuple with the field names
+// and aliases before the result tuples
+func (p *SQLParams) IncludeMetadata(includeMetadata bool) *SQLParams {
+	p.includeMetadata = includeMetadata
+	return p
+}
+
+// BuildParams builds the parameters
+func (p *SQLParams) BuildParams() string {
+	vals := &url.Values{}
+	vals.Add("stmt", p.stmt)
+
+	if p.aggregationMode != "" {
+		vals.Add("aggregationMode", p.aggregationMode)
+	}
+
+	if p.numWorkers > 0 {
+		vals.Add("numWorkers", strconv.Itoa(p.numWorkers))
+	}
+
+	if p.includeMetadata {
+		vals.Add("includeMetadata", "true")
+	}
+
+	return vals.Encode()
+}
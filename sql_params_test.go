@@ -0,0 +1,20 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildSQLParams(t *testing.T) {
+	got := solr.NewSQLParams("SELECT id FROM products").
+		AggregationMode(solr.AggregationModeMapReduce).
+		NumWorkers(2).
+		IncludeMetadata(true).
+		BuildParams()
+
+	expect := "aggregationMode=map_reduce&includeMetadata=true&numWorkers=2&stmt=SELECT+id+FROM+products"
+	assert.Equal(t, expect, got)
+}
@@ -0,0 +1,250 @@
+package solr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// StreamError is an EXCEPTION tuple returned by a streaming expression
+type StreamError struct {
+	Message string
+}
+
+// Error implements error
+func (e *StreamError) Error() string {
+	return "stream exception: " + e.Message
+}
+
+// TupleIterator iterates over the tuples of a streaming expression, SQL or export response.
+// The tuples are decoded one at a time as they are read from the response body.
+// The iterator must be closed if it's not iterated until the end.
+//
+//	it, err := client.Stream(ctx, "products", expr)
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//
+//	for it.Next() {
+//		tuple := it.Tuple()
+//		...
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type TupleIterator struct {
+	body io.ReadCloser
+	dec  *json.Decoder
+	// resultKey is the key of the object that contains the docs
+	// i.e. "result-set" for streams and "response" for exports
+	resultKey string
+	// eofTuple is true if the docs end with an EOF tuple
+	eofTuple bool
+	numFound int
+
+	started bool
+	done    bool
+	tuple   M
+	eof     M
+	err     error
+}
+
+// newTupleIterator returns a new TupleIterator that reads the tuples of a stream from body
+func newTupleIterator(body io.ReadCloser) *TupleIterator {
+	return &TupleIterator{
+		body:      body,
+		dec:       json.NewDecoder(body),
+		resultKey: "result-set",
+		eofTuple:  true,
+	}
+}
+
+// newExportIterator returns a new TupleIterator that reads the docs of an export from body.
+// The numbers are decoded as json.Number so that the long values keep their precision.
+func newExportIterator(body io.ReadCloser) *TupleIterator {
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+
+	return &TupleIterator{
+		body:      body,
+		dec:       dec,
+		resultKey: "response",
+	}
+}
+
+// Next advances the iterator to the next tuple. It returns false when
+// the EOF tuple or the end of the export is reached or if an error occurs.
+func (it *TupleIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+		err := it.seekDocs()
+		if err != nil {
+			it.fail(err)
+			return false
+		}
+	}
+
+	if !it.dec.More() {
+		if it.eofTuple {
+			it.fail(errors.New("stream ended without an EOF tuple"))
+			return false
+		}
+
+		it.drain()
+		return false
+	}
+
+	var tuple M
+	err := it.dec.Decode(&tuple)
+	if err != nil {
+		it.fail(wrapErr(err, "decode tuple"))
+		return false
+	}
+
+	if msg, ok := tuple["EXCEPTION"]; ok {
+		it.fail(&StreamError{Message: fmt.Sprint(msg)})
+		return false
+	}
+
+	if eof, _ := tuple["EOF"].(bool); eof {
+		it.eof = tuple
+		it.drain()
+		return false
+	}
+
+	it.tuple = tuple
+	return true
+}
+
+// Tuple returns the current tuple
+func (it *TupleIterator) Tuple() M {
+	return it.tuple
+}
+
+// Err returns the error encountered during the iteration
+func (it *TupleIterator) Err() error {
+	return it.err
+}
+
+// NumFound returns the number of documents of an export, available after the first call to Next
+func (it *TupleIterator) NumFound() int {
+	return it.numFound
+}
+
+// ResponseTime returns the RESPONSE_TIME of the EOF tuple
+// i.e. the time it took Solr to process the stream
+func (it *TupleIterator) ResponseTime() time.Duration {
+	ms, _ := toFloat64(it.eof["RESPONSE_TIME"])
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// Close stops the iteration and closes the response body
+func (it *TupleIterator) Close() error {
+	if it.done {
+		return nil
+	}
+
+	it.done = true
+	it.tuple = nil
+	return it.body.Close()
+}
+
+// drain reads the rest of the body so that the connection can be reused then closes it
+func (it *TupleIterator) drain() {
+	_, _ = io.Copy(io.Discard, it.body)
+	_ = it.Close()
+}
+
+// seekDocs advances the decoder to the start of the docs array
+func (it *TupleIterator) seekDocs() error {
+	err := expectDelim(it.dec, '{')
+	if err != nil {
+		return err
+	}
+
+	for it.dec.More() {
+		key, err := it.dec.Token()
+		if err != nil {
+			return wrapErr(err, "read key")
+		}
+
+		if key != it.resultKey {
+			err = skipValue(it.dec)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = expectDelim(it.dec, '{')
+		if err != nil {
+			return err
+		}
+
+		for it.dec.More() {
+			key, err := it.dec.Token()
+			if err != nil {
+				return wrapErr(err, "read key")
+			}
+
+			switch key {
+			case "docs":
+				return expectDelim(it.dec, '[')
+			case "numFound":
+				err = it.dec.Decode(&it.numFound)
+				if err != nil {
+					return wrapErr(err, "decode numFound")
+				}
+				continue
+			}
+
+			err = skipValue(it.dec)
+			if err != nil {
+				return err
+			}
+		}
+
+		return fmt.Errorf("missing %s docs", it.resultKey)
+	}
+
+	return fmt.Errorf("missing %s", it.resultKey)
+}
+
+// fail stops the iteration with the error
+func (it *TupleIterator) fail(err error) {
+	it.err = err
+	_ = it.Close()
+}
+
+// expectDelim reads the next token and checks that it's the delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return wrapErr(err, "read token")
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expecting %q but got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// skipValue skips the next value
+func skipValue(dec *json.Decoder) error {
+	var raw json.RawMessage
+	err := dec.Decode(&raw)
+	if err != nil {
+		return wrapErr(err, "skip value")
+	}
+
+	return nil
+}
@@ -0,0 +1,452 @@
+package solr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StreamExpression is an abstraction of a streaming expression
+// e.g. search, rollup, innerJoin, top, unique, facet etc.
+//
+// Refer to https://solr.apache.org/guide/8_8/streaming-expressions.html
+type StreamExpression interface {
+	// BuildExpression builds the streaming expression
+	BuildExpression() string
+}
+
+// RawExpression is a raw streaming expression string
+// e.g. `search(products, q="*:*", fl="id", sort="id asc")`
+type RawExpression string
+
+var _ StreamExpression = RawExpression("")
+
+// BuildExpression returns the raw expression
+func (e RawExpression) BuildExpression() string {
+	return string(e)
+}
+
+// StreamMetric is a metric used by the rollup and facet expressions e.g. sum(price_f)
+type StreamMetric string
+
+var _ StreamExpression = StreamMetric("")
+
+// BuildExpression returns the metric expression
+func (m StreamMetric) BuildExpression() string {
+	return string(m)
+}
+
+// MetricSum returns the sum metric of the field
+func MetricSum(field string) StreamMetric {
+	return StreamMetric("sum(" + field + ")")
+}
+
+// MetricMin returns the min metric of the field
+func MetricMin(field string) StreamMetric {
+	return StreamMetric("min(" + field + ")")
+}
+
+// MetricMax returns the max metric of the field
+func MetricMax(field string) StreamMetric {
+	return StreamMetric("max(" + field + ")")
+}
+
+// MetricAvg returns the avg metric of the field
+func MetricAvg(field string) StreamMetric {
+	return StreamMetric("avg(" + field + ")")
+}
+
+// MetricCount returns the count metric of the field, use "*" to count all tuples
+func MetricCount(field string) StreamMetric {
+	return StreamMetric("count(" + field + ")")
+}
+
+// streamParam is a named parameter of a stream function
+type streamParam struct {
+	name, value string
+}
+
+// streamFunction is the common builder of the stream functions
+type streamFunction struct {
+	name     string
+	operands []StreamExpression
+	params   []streamParam
+	metrics  []StreamMetric
+}
+
+// set sets the named parameter, replacing the previous value
+func (f *streamFunction) set(name, value string) {
+	for i, param := range f.params {
+		if param.name == name {
+			f.params[i].value = value
+			return
+		}
+	}
+
+	f.params = append(f.params, streamParam{name: name, value: value})
+}
+
+// build builds the function expression i.e. name(operands, params, metrics)
+func (f *streamFunction) build() string {
+	args := make([]string, 0, len(f.operands)+len(f.params)+len(f.metrics))
+	for _, operand := range f.operands {
+		args = append(args, operand.BuildExpression())
+	}
+
+	for _, param := range f.params {
+		args = append(args, param.name+"="+quoteParam(param.value))
+	}
+
+	for _, metric := range f.metrics {
+		args = append(args, metric.BuildExpression())
+	}
+
+	return f.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// quoteParam quotes the parameter value. The backslashes are kept as is since
+// the stream expression parser only unescapes the quotes, so a value can't end
+// with a backslash.
+func quoteParam(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// StreamFunction is a generic stream function builder for the functions
+// that doesn't have a dedicated builder e.g. merge, sort, reduce etc.
+type StreamFunction struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*StreamFunction)(nil)
+
+// NewStreamFunction returns a new StreamFunction
+func NewStreamFunction(name string) *StreamFunction {
+	return &StreamFunction{fn: streamFunction{name: name}}
+}
+
+// Operands adds the operands e.g. streams, collection names or metrics
+func (s *StreamFunction) Operands(operands ...StreamExpression) *StreamFunction {
+	s.fn.operands = append(s.fn.operands, operands...)
+	return s
+}
+
+// Param sets a named parameter
+func (s *StreamFunction) Param(name, value string) *StreamFunction {
+	s.fn.set(name, value)
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *StreamFunction) BuildExpression() string {
+	return s.fn.build()
+}
+
+// SearchStream is the search expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-source-reference.html#search
+type SearchStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*SearchStream)(nil)
+
+// NewSearchStream returns a new SearchStream for the collection
+func NewSearchStream(collection string) *SearchStream {
+	return &SearchStream{fn: streamFunction{
+		name:     "search",
+		operands: []StreamExpression{RawExpression(collection)},
+	}}
+}
+
+// Q sets the query
+func (s *SearchStream) Q(q string) *SearchStream {
+	s.fn.set("q", q)
+	return s
+}
+
+// Fl sets the fields to return
+func (s *SearchStream) Fl(fields ...string) *SearchStream {
+	s.fn.set("fl", strings.Join(fields, ","))
+	return s
+}
+
+// Sort sets the sort e.g. "id asc"
+func (s *SearchStream) Sort(sort string) *SearchStream {
+	s.fn.set("sort", sort)
+	return s
+}
+
+// Qt sets the request handler e.g. "/export" to stream the entire result set
+func (s *SearchStream) Qt(qt string) *SearchStream {
+	s.fn.set("qt", qt)
+	return s
+}
+
+// Rows sets the number of rows to return
+func (s *SearchStream) Rows(rows int) *SearchStream {
+	s.fn.set("rows", strconv.Itoa(rows))
+	return s
+}
+
+// Param sets a named parameter
+func (s *SearchStream) Param(name, value string) *SearchStream {
+	s.fn.set(name, value)
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *SearchStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// RollupStream is the rollup expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#rollup
+type RollupStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*RollupStream)(nil)
+
+// NewRollupStream returns a new RollupStream that groups the tuples of the stream.
+// The stream must be sorted by the over fields.
+func NewRollupStream(stream StreamExpression) *RollupStream {
+	return &RollupStream{fn: streamFunction{
+		name:     "rollup",
+		operands: []StreamExpression{stream},
+	}}
+}
+
+// Over sets the fields to group by
+func (s *RollupStream) Over(fields ...string) *RollupStream {
+	s.fn.set("over", strings.Join(fields, ","))
+	return s
+}
+
+// Metrics adds the metrics to compute for each group
+func (s *RollupStream) Metrics(metrics ...StreamMetric) *RollupStream {
+	s.fn.metrics = append(s.fn.metrics, metrics...)
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *RollupStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// InnerJoinStream is the innerJoin expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#innerjoin
+type InnerJoinStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*InnerJoinStream)(nil)
+
+// NewInnerJoinStream returns a new InnerJoinStream of the left and right streams.
+// Both streams must be sorted by the join fields.
+func NewInnerJoinStream(left, right StreamExpression) *InnerJoinStream {
+	return &InnerJoinStream{fn: streamFunction{
+		name:     "innerJoin",
+		operands: []StreamExpression{left, right},
+	}}
+}
+
+// On sets the join fields e.g. "personId=ownerId"
+func (s *InnerJoinStream) On(on string) *InnerJoinStream {
+	s.fn.set("on", on)
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *InnerJoinStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// TopStream is the top expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#top
+type TopStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*TopStream)(nil)
+
+// NewTopStream returns a new TopStream that emits the top n tuples of the stream
+func NewTopStream(n int, stream StreamExpression) *TopStream {
+	s := &TopStream{fn: streamFunction{
+		name:     "top",
+		operands: []StreamExpression{stream},
+	}}
+	s.fn.set("n", strconv.Itoa(n))
+	return s
+}
+
+// Sort sets the sort used to rank the tuples e.g. "price_f desc"
+func (s *TopStream) Sort(sort string) *TopStream {
+	s.fn.set("sort", sort)
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *TopStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// UniqueStream is the unique expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#unique
+type UniqueStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*UniqueStream)(nil)
+
+// NewUniqueStream returns a new UniqueStream that emits the unique tuples of the stream.
+// The stream must be sorted by the over fields.
+func NewUniqueStream(stream StreamExpression) *UniqueStream {
+	return &UniqueStream{fn: streamFunction{
+		name:     "unique",
+		operands: []StreamExpression{stream},
+	}}
+}
+
+// Over sets the fields used to determine the uniqueness
+func (s *UniqueStream) Over(fields ...string) *UniqueStream {
+	s.fn.set("over", strings.Join(fields, ","))
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *UniqueStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// FacetStream is the facet expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-source-reference.html#facet
+type FacetStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*FacetStream)(nil)
+
+// NewFacetStream returns a new FacetStream for the collection
+func NewFacetStream(collection string) *FacetStream {
+	return &FacetStream{fn: streamFunction{
+		name:     "facet",
+		operands: []StreamExpression{RawExpression(collection)},
+	}}
+}
+
+// Q sets the query
+func (s *FacetStream) Q(q string) *FacetStream {
+	s.fn.set("q", q)
+	return s
+}
+
+// Buckets sets the fields to bucket by
+func (s *FacetStream) Buckets(fields ...string) *FacetStream {
+	s.fn.set("buckets", strings.Join(fields, ","))
+	return s
+}
+
+// BucketSorts sets the bucket sorts e.g. "sum(price_f) desc"
+func (s *FacetStream) BucketSorts(sorts ...string) *FacetStream {
+	s.fn.set("bucketSorts", strings.Join(sorts, ","))
+	return s
+}
+
+// BucketSizeLimit sets the number of buckets to return
+func (s *FacetStream) BucketSizeLimit(limit int) *FacetStream {
+	s.fn.set("bucketSizeLimit", strconv.Itoa(limit))
+	return s
+}
+
+// Metrics adds the metrics to compute for each bucket
+func (s *FacetStream) Metrics(metrics ...StreamMetric) *FacetStream {
+	s.fn.metrics = append(s.fn.metrics, metrics...)
+	return s
+}
+
+// Param sets a named parameter
+func (s *FacetStream) Param(name, value string) *FacetStream {
+	s.fn.set(name, value)
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *FacetStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// UpdateStream is the update expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#update
+type UpdateStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*UpdateStream)(nil)
+
+// NewUpdateStream returns a new UpdateStream that indexes the tuples of the stream
+// into the destination collection
+func NewUpdateStream(destination string, stream StreamExpression) *UpdateStream {
+	return &UpdateStream{fn: streamFunction{
+		name:     "update",
+		operands: []StreamExpression{RawExpression(destination), stream},
+	}}
+}
+
+// BatchSize sets the number of tuples to index per batch
+func (s *UpdateStream) BatchSize(batchSize int) *UpdateStream {
+	s.fn.set("batchSize", strconv.Itoa(batchSize))
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *UpdateStream) BuildExpression() string {
+	return s.fn.build()
+}
+
+// DaemonStream is the daemon expression builder
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#daemon
+type DaemonStream struct {
+	fn streamFunction
+}
+
+var _ StreamExpression = (*DaemonStream)(nil)
+
+// NewDaemonStream returns a new DaemonStream that runs the stream in the background
+func NewDaemonStream(id string, stream StreamExpression) *DaemonStream {
+	s := &DaemonStream{fn: streamFunction{
+		name:     "daemon",
+		operands: []StreamExpression{stream},
+	}}
+	s.fn.set("id", id)
+	return s
+}
+
+// RunInterval sets the interval between the runs of the stream
+func (s *DaemonStream) RunInterval(interval time.Duration) *DaemonStream {
+	s.fn.set("runInterval", strconv.FormatInt(interval.Milliseconds(), 10))
+	return s
+}
+
+// QueueSize sets the size of the internal queue, required when the daemon is read
+func (s *DaemonStream) QueueSize(queueSize int) *DaemonStream {
+	s.fn.set("queueSize", strconv.Itoa(queueSize))
+	return s
+}
+
+// Terminate set to true to stop the daemon when the stream returns no tuples
+func (s *DaemonStream) Terminate(terminate bool) *DaemonStream {
+	s.fn.set("terminate", strconv.FormatBool(terminate))
+	return s
+}
+
+// BuildExpression builds the expression
+func (s *DaemonStream) BuildExpression() string {
+	return s.fn.build()
+}
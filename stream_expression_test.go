@@ -0,0 +1,119 @@
+package solr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestStreamExpressions(t *testing.T) {
+	search := solr.NewSearchStream("products").
+		Q("*:*").
+		Fl("id", "cat_s", "price_f").
+		Sort("cat_s asc").
+		Qt("/export")
+
+	var tests = []struct {
+		name   string
+		expr   solr.StreamExpression
+		expect string
+	}{
+		{
+			"search",
+			solr.NewSearchStream("products").Q(`name:"solr go"`).Rows(10).Param("df", "name").Q("*:*"),
+			`search(products, q="*:*", rows="10", df="name")`,
+		},
+		{
+			"rollup",
+			solr.NewRollupStream(search).Over("cat_s").
+				Metrics(solr.MetricSum("price_f"), solr.MetricMin("price_f"),
+					solr.MetricMax("price_f"), solr.MetricAvg("price_f"), solr.MetricCount("*")),
+			`rollup(search(products, q="*:*", fl="id,cat_s,price_f", sort="cat_s asc", qt="/export"), ` +
+				`over="cat_s", sum(price_f), min(price_f), max(price_f), avg(price_f), count(*))`,
+		},
+		{
+			"innerJoin",
+			solr.NewInnerJoinStream(
+				solr.NewSearchStream("people").Q("*:*").Sort("personId asc"),
+				solr.NewSearchStream("pets").Q("type:cat").Sort("ownerId asc"),
+			).On("personId=ownerId"),
+			`innerJoin(search(people, q="*:*", sort="personId asc"), ` +
+				`search(pets, q="type:cat", sort="ownerId asc"), on="personId=ownerId")`,
+		},
+		{
+			"top",
+			solr.NewTopStream(3, solr.RawExpression(`search(products, q="*:*")`)).Sort("price_f desc"),
+			`top(search(products, q="*:*"), n="3", sort="price_f desc")`,
+		},
+		{
+			"unique",
+			solr.NewUniqueStream(search).Over("cat_s"),
+			`unique(search(products, q="*:*", fl="id,cat_s,price_f", sort="cat_s asc", qt="/export"), over="cat_s")`,
+		},
+		{
+			"facet",
+			solr.NewFacetStream("products").Q("*:*").Buckets("cat_s").
+				BucketSorts("sum(price_f) desc").BucketSizeLimit(100).
+				Metrics(solr.MetricSum("price_f"), solr.MetricCount("*")),
+			`facet(products, q="*:*", buckets="cat_s", bucketSorts="sum(price_f) desc", ` +
+				`bucketSizeLimit="100", sum(price_f), count(*))`,
+		},
+		{
+			"update",
+			solr.NewUpdateStream("products_copy", solr.RawExpression("tuple(id=1)")).BatchSize(500),
+			`update(products_copy, tuple(id=1), batchSize="500")`,
+		},
+		{
+			"daemon",
+			solr.NewDaemonStream("copy", solr.NewUpdateStream("products_copy", search)).
+				RunInterval(time.Second).QueueSize(10).Terminate(true),
+			`daemon(update(products_copy, search(products, q="*:*", fl="id,cat_s,price_f", sort="cat_s asc", qt="/export")), ` +
+				`id="copy", runInterval="1000", queueSize="10", terminate="true")`,
+		},
+		{
+			"generic function",
+			solr.NewStreamFunction("merge").
+				Operands(solr.RawExpression("a"), solr.RawExpression("b")).
+				Param("on", "id asc"),
+			`merge(a, b, on="id asc")`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, test.expr.BuildExpression())
+		})
+	}
+
+	t.Run("quotes are escaped", func(t *testing.T) {
+		got := solr.NewSearchStream("products").Q(`name:"solr"`).BuildExpression()
+		assert.Equal(t, `search(products, q="name:\"solr\"")`, got)
+	})
+
+	t.Run("backslashes are kept", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			q      string
+			expect string
+		}{
+			{"escaped query chars", solr.EscapeQueryChars("a:b"), `search(products, q="a\:b")`},
+			{"regex", `name:/solr\d+/`, `search(products, q="name:/solr\d+/")`},
+			{"windows path", `path:"C:\data\solr"`, `search(products, q="path:\"C:\data\solr\"")`},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := solr.NewSearchStream("products").Q(test.q).BuildExpression()
+				assert.Equal(t, test.expect, got)
+
+				// the stream expression parser only turns \" back into "
+				quoted := strings.TrimSuffix(strings.TrimPrefix(got, `search(products, q="`), `")`)
+				assert.Equal(t, test.q, strings.ReplaceAll(quoted, `\"`, `"`))
+			})
+		}
+	})
+}
@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package solr
+
+import "iter"
+
+// All returns an iterator over the tuples. The iteration stops after yielding
+// the error if reading the stream fails. The response body is closed when the
+// iteration stops.
+func (it *TupleIterator) All() iter.Seq2[M, error] {
+	return func(yield func(M, error) bool) {
+		defer it.Close()
+
+		for it.Next() {
+			if !yield(it.Tuple(), nil) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+//go:build go1.23
+
+package solr_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestTupleIteratorAll(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	ctx := context.Background()
+	baseURL := "https://solr.example.com"
+	expr := solr.NewSearchStream("products").Q("*:*")
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		baseURL+"/solr/products/stream",
+		httpmock.NewStringResponder(http.StatusOK, `{"result-set":{"docs":[{"id":"1"},{"id":"2"},{"EXCEPTION":"boom","EOF":true}]}}`),
+	)
+
+	it, err := solr.NewJSONClient(baseURL).Stream(ctx, "products", expr)
+	require.NoError(t, err)
+
+	var (
+		ids  []interface{}
+		errs []error
+	)
+	for tuple, err := range it.All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, tuple["id"])
+	}
+
+	assert.Equal(t, []interface{}{"1", "2"}, ids)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "stream exception: boom")
+}
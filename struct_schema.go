@@ -0,0 +1,209 @@
+package solr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaFromStruct returns the fields and the copy fields of the struct using the `solr` tag options e.g.
+//
+//	type Product struct {
+//		ID       string    `solr:"id,type=string,indexed,stored,required"`
+//		Name     string    `solr:"name,type=text_general,indexed,stored,copyTo=_text_"`
+//		Tags     []string  `solr:"tags,indexed,stored,docValues"`
+//		Variants []Variant `solr:"variants"`
+//	}
+//
+// The supported options are type=<field type>, copyTo=<dest> (can be repeated), indexed, stored,
+// docValues, multiValued and required. If the type is not set, it's inferred from the Go type:
+//
+//	string                      string
+//	int8, int16, int32, uint8,
+//	uint16                      pint
+//	int, int64, uint, uint32,
+//	uint64                      plong
+//	float32                     pfloat
+//	float64                     pdouble
+//	bool                        boolean
+//	time.Time                   pdate
+//	[]byte                      binary
+//
+// Slices are multiValued. Struct fields and slices of structs are child documents,
+// their fields are added to the schema instead since Solr uses a single schema for
+// the parent and the child documents.
+func SchemaFromStruct(v interface{}) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expecting a struct but got %v", t)
+	}
+
+	sb := &structSchema{
+		schema:  &Schema{},
+		fields:  map[string]Field{},
+		visited: map[reflect.Type]bool{},
+	}
+	err := sb.addStruct(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return sb.schema, nil
+}
+
+// structSchema builds the schema of a struct and its child documents
+type structSchema struct {
+	schema *Schema
+	// fields is the fields added so far by name
+	fields map[string]Field
+	// visited is the struct types already added, for recursive child documents
+	visited map[reflect.Type]bool
+}
+
+func (sb *structSchema) addStruct(t reflect.Type) error {
+	if sb.visited[t] {
+		return nil
+	}
+	sb.visited[t] = true
+
+	for _, sf := range cachedFields(t) {
+		ft := t.FieldByIndex(sf.index).Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		multiValued := false
+		elemType := ft
+		if (ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array) && ft.Elem().Kind() != reflect.Uint8 {
+			multiValued = true
+			elemType = ft.Elem()
+			for elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+		}
+
+		// child documents
+		if elemType.Kind() == reflect.Struct && elemType != timeType && !hasTypeOption(sf.opts) {
+			err := sb.addStruct(elemType)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		field, copyFields, err := parseFieldOptions(sf.name, sf.opts)
+		if err != nil {
+			return err
+		}
+
+		if field.Type == "" {
+			field.Type = inferFieldType(elemType)
+			if field.Type == "" {
+				return fmt.Errorf("can't infer the field type of %q from %v, set the type option", sf.name, ft)
+			}
+		}
+
+		if multiValued {
+			field.MultiValued = Bool(true)
+		}
+
+		err = sb.addField(field)
+		if err != nil {
+			return err
+		}
+
+		sb.schema.CopyFields = append(sb.schema.CopyFields, copyFields...)
+	}
+
+	return nil
+}
+
+// addField adds the field unless a field with the same name was already added
+func (sb *structSchema) addField(field Field) error {
+	existing, ok := sb.fields[field.Name]
+	if !ok {
+		sb.fields[field.Name] = field
+		sb.schema.Fields = append(sb.schema.Fields, field)
+		return nil
+	}
+
+	if !reflect.DeepEqual(existing, field) {
+		return fmt.Errorf("conflicting definitions of field %q", field.Name)
+	}
+
+	return nil
+}
+
+// parseFieldOptions returns the field and the copy fields from the tag options
+func parseFieldOptions(name string, opts []string) (Field, []CopyField, error) {
+	field := Field{Name: name}
+	copyFields := []CopyField{}
+	for _, opt := range opts {
+		key, value, hasValue := strings.Cut(opt, "=")
+		switch {
+		case hasValue && key == "type":
+			field.Type = value
+		case hasValue && key == "copyTo":
+			copyFields = append(copyFields, CopyField{Source: name, Dest: value})
+		case opt == "indexed":
+			field.Indexed = Bool(true)
+		case opt == "stored":
+			field.Stored = Bool(true)
+		case opt == "docValues":
+			field.DocValues = Bool(true)
+		case opt == "multiValued":
+			field.MultiValued = Bool(true)
+		case opt == "required":
+			field.Required = Bool(true)
+		case opt == "omitempty", opt == "string":
+			// encoding options
+		default:
+			return Field{}, nil, fmt.Errorf("unknown option %q of field %q", opt, name)
+		}
+	}
+
+	return field, copyFields, nil
+}
+
+// hasTypeOption returns true if the tag options contain the type option
+func hasTypeOption(opts []string) bool {
+	for _, opt := range opts {
+		if strings.HasPrefix(opt, "type=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inferFieldType returns the field type of the Go type from the default configset
+func inferFieldType(t reflect.Type) string {
+	if t == timeType {
+		return "pdate"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "pint"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "plong"
+	case reflect.Float32:
+		return "pfloat"
+	case reflect.Float64:
+		return "pdouble"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "binary"
+		}
+	}
+
+	return ""
+}
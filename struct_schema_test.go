@@ -0,0 +1,93 @@
+package solr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+type schemaReview struct {
+	ID     string `solr:"id,type=string,indexed,stored,required"`
+	Rating int8   `solr:"rating_i,indexed,docValues"`
+	Text   string `solr:"text,type=text_general,stored,indexed,copyTo=_text_"`
+}
+
+type schemaComment struct {
+	ID      string           `solr:"id,type=string,indexed,stored,required"`
+	Replies []*schemaComment `solr:"replies"`
+}
+
+type schemaBook struct {
+	ID        string            `solr:"id,type=string,indexed,stored,required"`
+	Title     string            `solr:"title,type=text_general,indexed,stored,copyTo=_text_,copyTo=title_s"`
+	Authors   []string          `solr:"authors,indexed,stored"`
+	Pages     *int              `solr:"pages,docValues"`
+	Price     float64           `solr:"price,indexed,docValues"`
+	Weight    float32           `json:"weight,omitempty"`
+	InStock   bool              `solr:"inStock,indexed"`
+	Published time.Time         `solr:"published,indexed,stored"`
+	Cover     []byte            `solr:"cover,stored"`
+	Meta      map[string]string `solr:"meta_s,type=string,multiValued"`
+	Reviews   []schemaReview    `solr:"reviews"`
+	Comments  []schemaComment   `solr:"_childDocuments_"`
+	Ignored   string            `solr:"-"`
+	internal  string
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		schema, err := solr.SchemaFromStruct(&schemaBook{})
+		require.NoError(t, err)
+
+		expectFields := []solr.Field{
+			{Name: "id", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), Required: solr.Bool(true)},
+			{Name: "title", Type: "text_general", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+			{Name: "authors", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), MultiValued: solr.Bool(true)},
+			{Name: "pages", Type: "plong", DocValues: solr.Bool(true)},
+			{Name: "price", Type: "pdouble", Indexed: solr.Bool(true), DocValues: solr.Bool(true)},
+			{Name: "weight", Type: "pfloat"},
+			{Name: "inStock", Type: "boolean", Indexed: solr.Bool(true)},
+			{Name: "published", Type: "pdate", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+			{Name: "cover", Type: "binary", Stored: solr.Bool(true)},
+			{Name: "meta_s", Type: "string", MultiValued: solr.Bool(true)},
+			{Name: "rating_i", Type: "pint", Indexed: solr.Bool(true), DocValues: solr.Bool(true)},
+			{Name: "text", Type: "text_general", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+		}
+		assert.Equal(t, expectFields, schema.Fields)
+
+		expectCopyFields := []solr.CopyField{
+			{Source: "title", Dest: "_text_"},
+			{Source: "title", Dest: "title_s"},
+			{Source: "text", Dest: "_text_"},
+		}
+		assert.Equal(t, expectCopyFields, schema.CopyFields)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := solr.SchemaFromStruct("not a struct")
+		assert.EqualError(t, err, "expecting a struct but got string")
+
+		_, err = solr.SchemaFromStruct(nil)
+		assert.Error(t, err)
+
+		_, err = solr.SchemaFromStruct(struct {
+			Name string `solr:"name,stord"`
+		}{})
+		assert.EqualError(t, err, `unknown option "stord" of field "name"`)
+
+		_, err = solr.SchemaFromStruct(struct {
+			Attrs map[string]string `solr:"attrs"`
+		}{})
+		assert.EqualError(t, err, `can't infer the field type of "attrs" from map[string]string, set the type option`)
+
+		_, err = solr.SchemaFromStruct(struct {
+			ID     string         `solr:"id,indexed"`
+			Review []schemaReview `solr:"reviews"`
+		}{})
+		assert.EqualError(t, err, `conflicting definitions of field "id"`)
+	})
+}
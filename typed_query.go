@@ -0,0 +1,59 @@
+package solr
+
+import "context"
+
+// TypedQueryResponse is a query response with the documents decoded into T
+type TypedQueryResponse[T any] struct {
+	*BaseResponse
+	Response       TypedQueryResponseBody[T]
+	Facets         M
+	NextCursorMark string
+}
+
+// TypedQueryResponseBody is the query response body with the documents decoded into T
+type TypedQueryResponseBody[T any] struct {
+	NumFound  int
+	Start     int
+	MaxScore  float64
+	Documents []T
+}
+
+// QueryInto sends the query and decodes the documents into T. The numbers are
+// decoded exactly so that long values e.g. _version_ keep their precision.
+// Refer to DecodeDocument for how documents are decoded.
+func QueryInto[T any](ctx context.Context, client Client, collection string, query *Query) (*TypedQueryResponse[T], error) {
+	resp, err := client.Query(ctx, collection, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// the undecoded documents are decoded again with the numbers
+	// as json.Number so that the long values keep their precision
+	rawDocs := resp.Response.rawDocuments
+	docs := make([]T, len(resp.Response.Documents))
+	for i, doc := range resp.Response.Documents {
+		if len(rawDocs) == len(docs) {
+			doc, err = unmarshalDocument(rawDocs[i])
+			if err != nil {
+				return nil, wrapErr(err, "decode document")
+			}
+		}
+
+		err = DecodeDocument(doc, &docs[i])
+		if err != nil {
+			return nil, wrapErr(err, "decode document")
+		}
+	}
+
+	return &TypedQueryResponse[T]{
+		BaseResponse: resp.BaseResponse,
+		Response: TypedQueryResponseBody[T]{
+			NumFound:  resp.Response.NumFound,
+			Start:     resp.Response.Start,
+			MaxScore:  resp.Response.MaxScore,
+			Documents: docs,
+		},
+		Facets:         resp.Facets,
+		NextCursorMark: resp.NextCursorMark,
+	}, nil
+}
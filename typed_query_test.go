@@ -0,0 +1,92 @@
+package solr_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestQueryInto(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"responseHeader": {"status": 0},
+				"response": {
+					"numFound": 2,
+					"start": 0,
+					"docs": [
+						{"id": "p1", "name": ["Solr in Action"], "count_i": 1},
+						{"id": "p2", "name": ["Lucene in Action"], "count_i": 2}
+					]
+				},
+				"nextCursorMark": "AoE"
+			}`))
+		}))
+		defer ts.Close()
+
+		client := solr.NewJSONClient(ts.URL)
+		resp, err := solr.QueryInto[Product](ctx, client, "products", solr.NewQuery("*:*"))
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, resp.Response.NumFound)
+		assert.Equal(t, "AoE", resp.NextCursorMark)
+		require.Len(t, resp.Response.Documents, 2)
+		assert.Equal(t, "p1", resp.Response.Documents[0].ID)
+		assert.Equal(t, "Lucene in Action", resp.Response.Documents[1].Name)
+		assert.Equal(t, 2, resp.Response.Documents[1].Count)
+	})
+
+	t.Run("long values", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"response": {"numFound": 1, "docs": [
+				{"id": 9007199254740993, "_version_": 1712345678901234567}
+			]}}`))
+		}))
+		defer ts.Close()
+
+		type versioned struct {
+			ID      int64  `solr:"id"`
+			Version uint64 `solr:"_version_"`
+		}
+
+		client := solr.NewJSONClient(ts.URL)
+		resp, err := solr.QueryInto[versioned](ctx, client, "products", solr.NewQuery("*:*"))
+		require.NoError(t, err)
+		require.Len(t, resp.Response.Documents, 1)
+		assert.Equal(t, int64(9007199254740993), resp.Response.Documents[0].ID)
+		assert.Equal(t, uint64(1712345678901234567), resp.Response.Documents[0].Version)
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"response": {"numFound": 1, "docs": [{"count_i": "abc"}]}}`))
+		}))
+		defer ts.Close()
+
+		client := solr.NewJSONClient(ts.URL)
+		_, err := solr.QueryInto[Product](ctx, client, "products", solr.NewQuery("*:*"))
+		assert.Error(t, err)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		client := solr.NewJSONClient(ts.URL)
+		_, err := solr.QueryInto[Product](ctx, client, "products", solr.NewQuery("*:*"))
+		assert.True(t, solr.IsNotFound(err))
+	})
+}
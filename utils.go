@@ -24,3 +24,13 @@ func EscapeQueryChars(s string) string {
 	}
 	return sb.String()
 }
+
+// Bool returns a pointer to the bool e.g. for the Field and FieldType properties
+func Bool(v bool) *bool {
+	return &v
+}
+
+// boolValue returns the value of the bool pointer, false if nil
+func boolValue(v *bool) bool {
+	return v != nil && *v
+}
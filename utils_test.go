@@ -20,3 +20,13 @@ func TestEscapeQueryChars(t *testing.T) {
 	expected3 := `\	solr\ rocks`
 	a.Equal(expected3, EscapeQueryChars(queryWhitespace))
 }
+
+func TestBool(t *testing.T) {
+	a := assert.New(t)
+	a.True(*Bool(true))
+	a.False(*Bool(false))
+
+	a.True(boolValue(Bool(true)))
+	a.False(boolValue(Bool(false)))
+	a.False(boolValue(nil))
+}
@@ -0,0 +1,238 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaProblem is a problem found by ValidateSchema
+type SchemaProblem struct {
+	// Path is the location of the problem e.g. "fields[2].type"
+	Path string
+	// Message is the description of the problem
+	Message string
+}
+
+func (p SchemaProblem) Error() string {
+	return p.Path + ": " + p.Message
+}
+
+// tokenizedClasses is the field type classes that are tokenized and don't support docValues
+var tokenizedClasses = map[string]bool{
+	"TextField":        true,
+	"PreAnalyzedField": true,
+}
+
+// ValidateSchema checks the schema locally and returns the problems found:
+//
+//   - fields and dynamic fields without a name or referencing undefined field types
+//   - dynamic field names without a leading or trailing *
+//   - field types without a name or a class, analyzer tokenizers and filters without a class or a name
+//   - docValues on tokenized field types e.g. solr.TextField
+//   - copy field sources and dests that don't resolve to a field or a dynamic field
+//   - a missing or multiValued unique key
+//
+// All of the field types must be in the schema. To validate the changes to an existing
+// schema, add them to the schema returned by GetSchema before validating.
+func ValidateSchema(schema *Schema) []SchemaProblem {
+	v := &schemaValidator{
+		schema:     schema,
+		fieldTypes: map[string]FieldType{},
+		problems:   []SchemaProblem{},
+	}
+
+	v.validateFieldTypes()
+	v.validateFields("fields", schema.Fields)
+	v.validateFields("dynamicFields", schema.DynamicFields)
+	v.validateCopyFields()
+	v.validateUniqueKey()
+
+	return v.problems
+}
+
+type schemaValidator struct {
+	schema *Schema
+	// fieldTypes is the field types by name
+	fieldTypes map[string]FieldType
+	problems   []SchemaProblem
+}
+
+func (v *schemaValidator) addProblem(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, SchemaProblem{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *schemaValidator) validateFieldTypes() {
+	for i, ft := range v.schema.FieldTypes {
+		path := fmt.Sprintf("fieldTypes[%d]", i)
+		if ft.Name == "" {
+			v.addProblem(path+".name", "name is required")
+		} else if _, ok := v.fieldTypes[ft.Name]; ok {
+			v.addProblem(path+".name", "duplicate field type %q", ft.Name)
+		} else {
+			v.fieldTypes[ft.Name] = ft
+		}
+
+		if ft.Class == "" {
+			v.addProblem(path+".class", "class is required")
+		}
+
+		if boolValue(ft.DocValues) && isTokenized(ft) {
+			v.addProblem(path+".docValues", "docValues is not supported by tokenized class %s", ft.Class)
+		}
+
+		analyzers := []struct {
+			name     string
+			analyzer *Analyzer
+		}{
+			{"analyzer", ft.Analyzer},
+			{"indexAnalyzer", ft.IndexAnalyzer},
+			{"queryAnalyzer", ft.QueryAnalyzer},
+		}
+		for _, a := range analyzers {
+			if a.analyzer != nil {
+				v.validateAnalyzer(path+"."+a.name, a.analyzer)
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateAnalyzer(path string, analyzer *Analyzer) {
+	if analyzer.Class != "" {
+		return
+	}
+
+	if analyzer.Tokenizer == nil {
+		v.addProblem(path+".tokenizer", "tokenizer is required")
+	} else if analyzer.Tokenizer.Class == "" && analyzer.Tokenizer.Name == "" {
+		v.addProblem(path+".tokenizer.class", "class or name is required")
+	}
+
+	for i, filter := range analyzer.CharFilters {
+		if filter.Class == "" && filter.Name == "" {
+			v.addProblem(fmt.Sprintf("%s.charFilters[%d].class", path, i), "class or name is required")
+		}
+	}
+
+	for i, filter := range analyzer.Filters {
+		if filter.Class == "" && filter.Name == "" {
+			v.addProblem(fmt.Sprintf("%s.filters[%d].class", path, i), "class or name is required")
+		}
+	}
+}
+
+func (v *schemaValidator) validateFields(kind string, fields []Field) {
+	names := map[string]bool{}
+	for i, field := range fields {
+		path := fmt.Sprintf("%s[%d]", kind, i)
+		switch {
+		case field.Name == "":
+			v.addProblem(path+".name", "name is required")
+		case names[field.Name]:
+			v.addProblem(path+".name", "duplicate name %q", field.Name)
+		case kind == "dynamicFields" && !isDynamicFieldName(field.Name):
+			v.addProblem(path+".name", "dynamic field name %q must start or end with *", field.Name)
+		}
+		names[field.Name] = true
+
+		if field.Type == "" {
+			v.addProblem(path+".type", "type is required")
+			continue
+		}
+
+		ft, ok := v.fieldTypes[field.Type]
+		if !ok {
+			v.addProblem(path+".type", "undefined field type %q", field.Type)
+			continue
+		}
+
+		if boolValue(field.DocValues) && isTokenized(ft) {
+			v.addProblem(path+".docValues", "docValues is not supported by field type %q with tokenized class %s",
+				ft.Name, ft.Class)
+		}
+	}
+}
+
+func (v *schemaValidator) validateCopyFields() {
+	for i, cf := range v.schema.CopyFields {
+		path := fmt.Sprintf("copyFields[%d]", i)
+
+		sourceGlob := strings.Contains(cf.Source, "*")
+		switch {
+		case cf.Source == "":
+			v.addProblem(path+".source", "source is required")
+		case sourceGlob && !isDynamicFieldName(cf.Source):
+			v.addProblem(path+".source", "source pattern %q must start or end with *", cf.Source)
+		case !sourceGlob && !v.resolveField(cf.Source):
+			v.addProblem(path+".source", "source %q doesn't match a field or a dynamic field", cf.Source)
+		}
+
+		switch {
+		case cf.Dest == "":
+			v.addProblem(path+".dest", "dest is required")
+		case strings.Contains(cf.Dest, "*"):
+			if !sourceGlob {
+				v.addProblem(path+".dest", "dest pattern %q requires a source pattern", cf.Dest)
+			} else if !isDynamicFieldName(cf.Dest) {
+				v.addProblem(path+".dest", "dest pattern %q must start or end with *", cf.Dest)
+			}
+		case !v.resolveField(cf.Dest):
+			v.addProblem(path+".dest", "dest %q doesn't match a field or a dynamic field", cf.Dest)
+		}
+	}
+}
+
+func (v *schemaValidator) validateUniqueKey() {
+	if v.schema.UniqueKey == "" {
+		return
+	}
+
+	for _, field := range v.schema.Fields {
+		if field.Name != v.schema.UniqueKey {
+			continue
+		}
+
+		// the field inherits multiValued from the field type if not set
+		multiValued := field.MultiValued
+		if multiValued == nil {
+			multiValued = v.fieldTypes[field.Type].MultiValued
+		}
+
+		if boolValue(multiValued) {
+			v.addProblem("uniqueKey", "unique key field %q can't be multiValued", field.Name)
+		}
+		return
+	}
+
+	v.addProblem("uniqueKey", "unique key field %q is not defined", v.schema.UniqueKey)
+}
+
+// resolveField returns true if the name is a field or matches a dynamic field
+func (v *schemaValidator) resolveField(name string) bool {
+	for _, field := range v.schema.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+
+	_, ok := matchDynamicField(name, v.schema.DynamicFields)
+	return ok
+}
+
+// isDynamicFieldName returns true if the name has a single * at the start or at the end
+func isDynamicFieldName(name string) bool {
+	return strings.Count(name, "*") == 1 &&
+		(strings.HasPrefix(name, "*") || strings.HasSuffix(name, "*"))
+}
+
+// isTokenized returns true if the field type class is tokenized
+func isTokenized(ft FieldType) bool {
+	class := ft.Class
+	if i := strings.LastIndex(class, "."); i >= 0 {
+		class = class[i+1:]
+	}
+
+	return tokenizedClasses[class]
+}
@@ -0,0 +1,147 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestValidateSchema(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		schema := &solr.Schema{
+			UniqueKey: "id",
+			FieldTypes: []solr.FieldType{
+				{Name: "string", Class: "solr.StrField", DocValues: solr.Bool(true)},
+				{
+					Name:     "text_general",
+					Class:    "solr.TextField",
+					Analyzer: solr.NewAnalyzer(solr.NewStandardTokenizer(), solr.NewLowerCaseFilter()),
+				},
+				{
+					Name:     "text_ws",
+					Class:    "solr.TextField",
+					Analyzer: &solr.Analyzer{Class: "org.apache.lucene.analysis.core.WhitespaceAnalyzer"},
+				},
+				{
+					Name:  "text_lower",
+					Class: "solr.TextField",
+					Analyzer: &solr.Analyzer{
+						Tokenizer: &solr.Tokenizer{Name: "standard"},
+						Filters:   []solr.Filter{{Name: "lowercase"}},
+					},
+				},
+			},
+			Fields: []solr.Field{
+				{Name: "id", Type: "string", Indexed: solr.Bool(true), Stored: solr.Bool(true), Required: solr.Bool(true)},
+				{Name: "name", Type: "text_general", Indexed: solr.Bool(true), Stored: solr.Bool(true)},
+				{Name: "_text_", Type: "text_general", MultiValued: solr.Bool(true)},
+			},
+			DynamicFields: []solr.Field{
+				{Name: "*_s", Type: "string", DocValues: solr.Bool(true)},
+				{Name: "attr_*", Type: "text_general"},
+			},
+			CopyFields: []solr.CopyField{
+				{Source: "name", Dest: "_text_"},
+				{Source: "*_s", Dest: "_text_"},
+				{Source: "name", Dest: "name_s"},
+				{Source: "*_s", Dest: "attr_*"},
+			},
+		}
+
+		assert.Empty(t, solr.ValidateSchema(schema))
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		schema := &solr.Schema{
+			UniqueKey: "id",
+			FieldTypes: []solr.FieldType{
+				{Name: "string", Class: "solr.StrField"},
+				{Name: "string", Class: "solr.StrField"},
+				{Name: "text", Class: "org.apache.solr.schema.TextField", DocValues: solr.Bool(true),
+					IndexAnalyzer: &solr.Analyzer{
+						Tokenizer: &solr.Tokenizer{},
+						Filters:   []solr.Filter{{Class: "solr.LowerCaseFilterFactory"}, {}},
+					},
+					QueryAnalyzer: &solr.Analyzer{
+						CharFilters: []solr.Filter{{}},
+					},
+				},
+				{Class: "solr.BoolField"},
+			},
+			Fields: []solr.Field{
+				{Name: "id", Type: "string", MultiValued: solr.Bool(true)},
+				{Name: "title", Type: "text", DocValues: solr.Bool(true)},
+				{Name: "price", Type: "pfloat"},
+				{Name: "title", Type: "text"},
+				{Type: "string"},
+				{Name: "notype"},
+			},
+			DynamicFields: []solr.Field{
+				{Name: "attr", Type: "string"},
+				{Name: "*_x_*", Type: "string"},
+			},
+			CopyFields: []solr.CopyField{
+				{Source: "missing", Dest: "title"},
+				{Source: "title", Dest: "missing"},
+				{Source: "title", Dest: "*_s"},
+				{Source: "a*b", Dest: "title"},
+				{Source: "*_t", Dest: "x*y"},
+				{},
+			},
+		}
+
+		expect := []solr.SchemaProblem{
+			{Path: "fieldTypes[1].name", Message: `duplicate field type "string"`},
+			{Path: "fieldTypes[2].docValues", Message: "docValues is not supported by tokenized class org.apache.solr.schema.TextField"},
+			{Path: "fieldTypes[2].indexAnalyzer.tokenizer.class", Message: "class or name is required"},
+			{Path: "fieldTypes[2].indexAnalyzer.filters[1].class", Message: "class or name is required"},
+			{Path: "fieldTypes[2].queryAnalyzer.tokenizer", Message: "tokenizer is required"},
+			{Path: "fieldTypes[2].queryAnalyzer.charFilters[0].class", Message: "class or name is required"},
+			{Path: "fieldTypes[3].name", Message: "name is required"},
+			{Path: "fields[1].docValues", Message: `docValues is not supported by field type "text" with tokenized class org.apache.solr.schema.TextField`},
+			{Path: "fields[2].type", Message: `undefined field type "pfloat"`},
+			{Path: "fields[3].name", Message: `duplicate name "title"`},
+			{Path: "fields[4].name", Message: "name is required"},
+			{Path: "fields[5].type", Message: "type is required"},
+			{Path: "dynamicFields[0].name", Message: `dynamic field name "attr" must start or end with *`},
+			{Path: "dynamicFields[1].name", Message: `dynamic field name "*_x_*" must start or end with *`},
+			{Path: "copyFields[0].source", Message: `source "missing" doesn't match a field or a dynamic field`},
+			{Path: "copyFields[1].dest", Message: `dest "missing" doesn't match a field or a dynamic field`},
+			{Path: "copyFields[2].dest", Message: `dest pattern "*_s" requires a source pattern`},
+			{Path: "copyFields[3].source", Message: `source pattern "a*b" must start or end with *`},
+			{Path: "copyFields[4].dest", Message: `dest pattern "x*y" must start or end with *`},
+			{Path: "copyFields[5].source", Message: "source is required"},
+			{Path: "copyFields[5].dest", Message: "dest is required"},
+			{Path: "uniqueKey", Message: `unique key field "id" can't be multiValued`},
+		}
+		assert.Equal(t, expect, solr.ValidateSchema(schema))
+	})
+
+	t.Run("multiValued unique key from field type", func(t *testing.T) {
+		problems := solr.ValidateSchema(&solr.Schema{
+			UniqueKey:  "id",
+			FieldTypes: []solr.FieldType{{Name: "strings", Class: "solr.StrField", MultiValued: solr.Bool(true)}},
+			Fields:     []solr.Field{{Name: "id", Type: "strings"}},
+		})
+		assert.Equal(t, []solr.SchemaProblem{
+			{Path: "uniqueKey", Message: `unique key field "id" can't be multiValued`},
+		}, problems)
+
+		problems = solr.ValidateSchema(&solr.Schema{
+			UniqueKey:  "id",
+			FieldTypes: []solr.FieldType{{Name: "strings", Class: "solr.StrField", MultiValued: solr.Bool(true)}},
+			Fields:     []solr.Field{{Name: "id", Type: "strings", MultiValued: solr.Bool(false)}},
+		})
+		assert.Empty(t, problems)
+	})
+
+	t.Run("missing unique key", func(t *testing.T) {
+		problems := solr.ValidateSchema(&solr.Schema{UniqueKey: "id"})
+		assert.Equal(t, []solr.SchemaProblem{
+			{Path: "uniqueKey", Message: `unique key field "id" is not defined`},
+		}, problems)
+		assert.EqualError(t, problems[0], `uniqueKey: unique key field "id" is not defined`)
+	})
+}